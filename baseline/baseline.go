@@ -0,0 +1,202 @@
+// Package baseline provides the WhaleLint baseline file support.
+//
+// A baseline records the violations of a Dockerfile set at a given time, so that only new violations are reported
+// afterwards. This makes it possible to introduce WhaleLint to legacy repositories gradually.
+//
+// Each entry is identified by its rule ID, file path and the fingerprint of the offending instruction's source code,
+// see RuleValidationResult.Fingerprint. The file paths are relative to the directory of the baseline file, so entries
+// match regardless of the working directory and of how the Dockerfile is passed, e.g. ./Dockerfile or as an absolute
+// path. Line numbers are recorded for information only, so entries keep matching when unrelated lines are added or
+// removed.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// DefaultFileName is the default path of the baseline file.
+const DefaultFileName = ".whalelint-baseline.json"
+
+// Version is the version of the baseline file format.
+const Version = 1
+
+// Baseline represents a WhaleLint baseline file.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+
+	// dir is the directory of the baseline file, that the file paths of the entries are relative to.
+	dir string
+}
+
+// Entry is a recorded violation.
+type Entry struct {
+	RuleID      string `json:"ruleId"`
+	FilePath    string `json:"filePath"`
+	Fingerprint string `json:"fingerprint"`
+	Line        int    `json:"line"`
+	Message     string `json:"message"`
+}
+
+// New creates a baseline from the violations of the rule validation results, that is to be saved at filePath.
+func New(ruleValidationResultArray []RuleSet.RuleValidationResult, filePath string) *Baseline {
+	baseline := &Baseline{Version: Version, Entries: []Entry{}, dir: filepath.Dir(filePath)}
+
+	for i := range ruleValidationResultArray {
+		result := &ruleValidationResultArray[i]
+		if !result.IsViolated() {
+			continue
+		}
+
+		line := 0
+		if start := result.LocationRange.Start(); start != nil {
+			line = start.LineNumber()
+		}
+
+		relativeFilePath, fingerprint := baseline.fingerprint(result)
+
+		baseline.Entries = append(baseline.Entries, Entry{
+			RuleID:      result.RuleID(),
+			FilePath:    relativeFilePath,
+			Fingerprint: fingerprint,
+			Line:        line,
+			Message:     result.Message(),
+		})
+	}
+
+	// keep the file diff friendly
+	sort.SliceStable(baseline.Entries, func(i, j int) bool {
+		if baseline.Entries[i].FilePath != baseline.Entries[j].FilePath {
+			return baseline.Entries[i].FilePath < baseline.Entries[j].FilePath
+		}
+
+		return baseline.Entries[i].Line < baseline.Entries[j].Line
+	})
+
+	return baseline
+}
+
+// Load reads the baseline file at filePath.
+func Load(filePath string) (*Baseline, error) {
+	fileContent, err := Utils.ReadFileContents(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("baseline | %w", err)
+	}
+
+	baseline := &Baseline{dir: filepath.Dir(filePath)}
+
+	if err := json.Unmarshal([]byte(fileContent), baseline); err != nil {
+		return nil, fmt.Errorf("baseline | %s | %w", filePath, err)
+	}
+
+	return baseline, nil
+}
+
+// Save writes the baseline to the file at filePath.
+func (baseline *Baseline) Save(filePath string) error {
+	fileHandle, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("baseline | %w", err)
+	}
+	defer fileHandle.Close()
+
+	return baseline.Write(fileHandle)
+}
+
+// Write writes the baseline in JSON format to writer.
+func (baseline *Baseline) Write(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(baseline); err != nil {
+		return fmt.Errorf("baseline | %w", err)
+	}
+
+	return nil
+}
+
+// Filter clears the violation of the results that are recorded in the baseline, so only the new ones are reported.
+// Each entry matches one violation at most, so an additional violation of the same instruction is still reported.
+// It returns the stale entries, i.e. the ones of the linted Dockerfiles, that no longer occur. The entries of the
+// Dockerfiles, that have not been linted, e.g. as only a single Dockerfile of the baseline was linted, are not stale.
+func (baseline *Baseline) Filter(ruleValidationResultArray []RuleSet.RuleValidationResult) []Entry {
+	// number of not yet matched entries per key
+	entryCountMap := make(map[string]int)
+	for _, entry := range baseline.Entries {
+		entryCountMap[entry.key()]++
+	}
+
+	// every linted Dockerfile has results, even if it has no violations
+	lintedFilePathMap := make(map[string]bool)
+
+	for i := range ruleValidationResultArray {
+		result := &ruleValidationResultArray[i]
+		relativeFilePath, fingerprint := baseline.fingerprint(result)
+		lintedFilePathMap[relativeFilePath] = true
+
+		if !result.IsViolated() {
+			continue
+		}
+
+		key := Entry{Fingerprint: fingerprint}.key() // nolint:exhaustivestruct
+		if entryCountMap[key] > 0 {
+			entryCountMap[key]--
+			result.SetViolated(false, RuleSet.FORCE)
+		}
+	}
+
+	staleEntryList := make([]Entry, 0)
+
+	// the entries in the back are considered stale, as the violations are matched in order of appearance
+	for i := len(baseline.Entries) - 1; i >= 0; i-- {
+		entry := baseline.Entries[i]
+		if entryCountMap[entry.key()] > 0 && lintedFilePathMap[entry.FilePath] {
+			entryCountMap[entry.key()]--
+			staleEntryList = append([]Entry{entry}, staleEntryList...)
+		}
+	}
+
+	return staleEntryList
+}
+
+// fingerprint returns the file path of the result relative to the directory of the baseline and the fingerprint of
+// the result with that file path.
+func (baseline *Baseline) fingerprint(result *RuleSet.RuleValidationResult) (string, string) {
+	relativeResult := *result
+	relativeResult.SetFilePath(relativePath(baseline.dir, result.FilePath()))
+
+	return relativeResult.FilePath(), relativeResult.Fingerprint()
+}
+
+// relativePath returns filePath relative to dir with forward slashes. Both of them are resolved against the working
+// directory, if they are relative. It falls back to the cleaned filePath, if it cannot be made relative, e.g. on
+// another Windows drive.
+func relativePath(dir string, filePath string) string {
+	if filePath == "" {
+		return ""
+	}
+
+	absDir, errDir := filepath.Abs(dir)
+	absFilePath, errFilePath := filepath.Abs(filePath)
+
+	if errDir == nil && errFilePath == nil {
+		if relativeFilePath, err := filepath.Rel(absDir, absFilePath); err == nil {
+			return filepath.ToSlash(relativeFilePath)
+		}
+	}
+
+	return filepath.ToSlash(filepath.Clean(filePath))
+}
+
+// key identifies the entry. The fingerprint already covers the rule ID and the file path relative to the baseline.
+func (entry Entry) key() string {
+	return entry.Fingerprint
+}
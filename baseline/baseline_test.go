@@ -0,0 +1,137 @@
+package baseline_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	Baseline "github.com/cremindes/whalelint/baseline"
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func newResult(t *testing.T, ruleID string, line int, filePath, instruction string) RuleSet.RuleValidationResult {
+	t.Helper()
+
+	rule := RuleSet.Get().GetRuleByName(ruleID, nil)
+	result := RuleSet.NewRuleValidationResult(&rule, true, "", RuleSet.NewLocationRange(line, 0, line, 1))
+	result.SetFilePath(filePath)
+	result.SetInstruction(instruction)
+
+	return *result
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "RUN004", 3, "api/Dockerfile", "RUN sudo ls"),
+		newResult(t, "STS001", 1, "Dockerfile", "FROM golang"),
+		newResult(t, "RUN004", 2, "Dockerfile", "RUN sudo ls"),
+	}
+	results[1].SetViolated(false, RuleSet.FORCE)
+
+	baseline := Baseline.New(results, Baseline.DefaultFileName)
+
+	assert.Equal(t, Baseline.Version, baseline.Version)
+	assert.Equal(t, 2, len(baseline.Entries))
+
+	// sorted by file path and line
+	assert.Equal(t, Baseline.Entry{
+		RuleID:      "RUN004",
+		FilePath:    "Dockerfile",
+		Fingerprint: results[2].Fingerprint(),
+		Line:        2,
+		Message:     results[2].Message(),
+	}, baseline.Entries[0])
+	assert.Equal(t, "api/Dockerfile", baseline.Entries[1].FilePath)
+}
+
+func TestBaseline_Filter(t *testing.T) {
+	t.Parallel()
+
+	baseline := Baseline.New([]RuleSet.RuleValidationResult{
+		newResult(t, "STS001", 1, "Dockerfile", "FROM golang"),
+		newResult(t, "RUN004", 2, "Dockerfile", "RUN sudo ls"),
+		newResult(t, "RUN004", 3, "Dockerfile", "RUN sudo  make install"),
+	}, Baseline.DefaultFileName)
+
+	// a line was added at the beginning, "RUN sudo make install" was removed and "RUN sudo ls" was duplicated
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "STS001", 2, "./Dockerfile", "FROM   golang"),
+		newResult(t, "RUN004", 3, "./Dockerfile", "RUN sudo ls"),
+		newResult(t, "RUN004", 4, "./Dockerfile", "RUN sudo ls"),
+	}
+
+	staleEntryList := baseline.Filter(results)
+
+	assert.False(t, results[0].IsViolated())
+	assert.False(t, results[1].IsViolated())
+	assert.True(t, results[2].IsViolated())
+
+	assert.Equal(t, 1, len(staleEntryList))
+	assert.Equal(t, 3, staleEntryList[0].Line)
+}
+
+func TestBaseline_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), Baseline.DefaultFileName)
+	baseline := Baseline.New([]RuleSet.RuleValidationResult{
+		newResult(t, "RUN004", 2, "Dockerfile", "RUN sudo ls"),
+	}, filePath)
+
+	assert.Nil(t, baseline.Save(filePath))
+
+	loadedBaseline, err := Baseline.Load(filePath)
+	assert.Nil(t, err)
+	assert.Equal(t, baseline, loadedBaseline)
+
+	_, err = Baseline.Load(filePath + ".missing")
+	assert.NotNil(t, err)
+}
+
+func TestBaseline_Filter_NotLinted(t *testing.T) {
+	t.Parallel()
+
+	baseline := Baseline.New([]RuleSet.RuleValidationResult{
+		newResult(t, "RUN004", 2, "api/Dockerfile", "RUN sudo ls"),
+		newResult(t, "RUN004", 2, "web/Dockerfile", "RUN sudo ls"),
+		newResult(t, "RUN004", 3, "web/Dockerfile", "RUN sudo make"),
+	}, Baseline.DefaultFileName)
+
+	// only web/Dockerfile is linted, where "RUN sudo make" was removed
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "RUN004", 2, "web/Dockerfile", "RUN sudo ls"),
+	}
+
+	staleEntryList := baseline.Filter(results)
+
+	assert.False(t, results[0].IsViolated())
+	assert.Equal(t, 1, len(staleEntryList))
+	assert.Equal(t, "web/Dockerfile", staleEntryList[0].FilePath)
+	assert.Equal(t, 3, staleEntryList[0].Line)
+}
+
+func TestBaseline_Filter_FilePath(t *testing.T) {
+	t.Parallel()
+
+	workingDir, err := os.Getwd()
+	assert.Nil(t, err)
+
+	// the file paths are recorded relative to the baseline file
+	baseline := Baseline.New([]RuleSet.RuleValidationResult{
+		newResult(t, "RUN004", 2, "./docker/api/Dockerfile", "RUN sudo ls"),
+	}, filepath.Join("docker", Baseline.DefaultFileName))
+
+	assert.Equal(t, "api/Dockerfile", baseline.Entries[0].FilePath)
+
+	// the same Dockerfile as an absolute path, e.g. passed from another working directory
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "RUN004", 2, filepath.Join(workingDir, "docker", "api", "Dockerfile"), "RUN sudo ls"),
+	}
+
+	assert.Equal(t, 0, len(baseline.Filter(results)))
+	assert.False(t, results[0].IsViolated())
+}
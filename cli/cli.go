@@ -3,15 +3,18 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/alecthomas/kong"
 	log "github.com/sirupsen/logrus"
 
+	Baseline "github.com/cremindes/whalelint/baseline"
+	Config "github.com/cremindes/whalelint/config"
 	Linter "github.com/cremindes/whalelint/linter"
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
 	Lsp "github.com/cremindes/whalelint/lsp"
-	Parser "github.com/cremindes/whalelint/parser"
 	Report "github.com/cremindes/whalelint/report"
 	Utils "github.com/cremindes/whalelint/utils"
 )
@@ -23,22 +26,56 @@ Commands:
   help [automatic]
   lsp
 	--port
+    --stdio
     -c, --config
+  baseline
+    create
+      --output
+      file and/or directory list
+  fix
+    --dry-run
+    --diff
+    --include, --exclude
+    file and/or directory list
   lint [default]
-    --format [json, summary]
+    --baseline
+    --format [checkstyle, github, gitlab, json, junit, sarif, summary]
     --return-value [app, bool, num]
+    --fail-on [error, warning, info, deprecation]
     --verbosity [short, normal, high]
-    file list
+    --include, --exclude
+    --stdin-filename
+    file and/or directory list, "-" for stdin
 	-c, --config
+  rules
+    --format [json, table]
+  explain
+    rule ID
   version
 */
 
 type WhaleLintCLI struct {
-	Lint    LintCommand    `kong:"cmd,help='run linter.'"`
-	Lsp     LspCommand     `kong:"cmd,help='run language server'"`
-	Version VersionCommand `kong:"cmd,help='show version.'"`
+	Baseline BaselineCommand `kong:"cmd,help='manage the baseline file of pre-existing violations.'"`
+	Explain  ExplainCommand  `kong:"cmd,help='explain a rule with examples.'"`
+	Fix      FixCommand      `kong:"cmd,help='apply the autofixes of the fixable rules in place.'"`
+	Lint     LintCommand     `kong:"cmd,help='run linter.'"`
+	Lsp      LspCommand      `kong:"cmd,help='run language server'"`
+	Rules    RulesCommand    `kong:"cmd,help='list rules.'"`
+	Version  VersionCommand  `kong:"cmd,help='show version.'"`
 
-	Config  string         `kong:"help='config file path NOTIMPLEMENTED'"` // nolint:gofmt,gofumpt,goimports
+	// Stdin is read, when "-" is given as a path. Defaults to os.Stdin.
+	Stdin io.Reader `kong:"-"`
+
+	Config  string         `kong:"help='Config file path. By default .whalelint.yml is searched for next to the Dockerfile and in its parent directories.',type='path'"` // nolint:gofmt,gofumpt,goimports,lll
+}
+
+// stdin returns the reader of the Dockerfile given as "-".
+func (cli *WhaleLintCLI) stdin() io.Reader {
+	if cli.Stdin == nil {
+		return os.Stdin
+	}
+
+	return cli.Stdin
 }
 
 func (*WhaleLintCLI) Options() []kong.Option {
@@ -46,10 +83,10 @@ func (*WhaleLintCLI) Options() []kong.Option {
 		kong.Name("whalelint"),
 		kong.Description("WhaleLint is a Dockerfile linter. It can function as\n" +
 			"  - CLI linter as default\n" +
-			"  - Pre-commit hook with option --return-value=\"errnum\"\n" +
-			"  - CI linter with options like --format=json and/or --return-value=\"errnum\"\n" +
+			"  - Pre-commit hook with option --return-value=\"bool\"\n" +
+			"  - CI linter with options like --format=json, --return-value=\"num\" and/or --fail-on=\"error\"\n" +
 			"  - Language server for plugins\n" +
-			" (- GitHub Action - in the roadmap)" + "\n" +
+			"  - GitHub Actions annotations and GitLab Code Quality reports with --format=github and --format=gitlab\n" +
 			"More documentation at https://github.com/CreMindES/whalelint"),
 		kong.UsageOnError(),
 		kong.ConfigureHelp(kong.HelpOptions{ // nolint:exhaustivestruct
@@ -58,52 +95,79 @@ func (*WhaleLintCLI) Options() []kong.Option {
 	}
 }
 
+// MaxExitCode is the highest exit code used by --return-value=num, as codes above it have special meaning in shells.
+const MaxExitCode = 125
+
+// ExitCodeError signals that the process should exit with Code, as requested by --return-value and --fail-on.
+type ExitCodeError struct {
+	Code int
+}
+
+func (exitCodeError *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit code %d", exitCodeError.Code)
+}
+
 type LintCommand struct {
-	Format      string   `kong:"help='Report format [${enum}].',default='summary',enum='json, summary'"`
+	Baseline    string   `kong:"help='Baseline file path. Violations recorded in it are not reported.',type='path'"`
+	Exclude     []string `kong:"help='Glob of files to skip while walking directories, e.g. **/test/**.'"`
+	FailOn      string   `kong:"help='Minimum severity of violations that fail the run [error, warning, info, deprecation]. By default every violation counts for bool and num return values.'"` // nolint:lll
+	Format      string   `kong:"help='Report format [${enum}].',default='summary',enum='checkstyle, github, gitlab, json, junit, sarif, summary'"` // nolint:lll
+	Include     []string `kong:"help='Glob of additional files to lint while walking directories, e.g. *.docker.'"`
 	NoColor     bool     `kong:"help='No color output'"`
-	Paths       []string `kong:"arg,required,help='Path to Dockerfile.',type:'path'"`
-	ReturnValue string   `kong:"help='Set return value to one of [${enum}] NOT_IMPLEMENTED.',default='app',enum='app, bool, num'"` // nolint:lll
+	Paths       []string `kong:"arg,required,help='Path to Dockerfile or directory to walk, - to read a Dockerfile from stdin.',type:'path'"` // nolint:lll
+	ReturnValue string   `kong:"help='Set return value to one of [${enum}]: app - non-zero on application errors or on violations, if --fail-on is set; bool - 1 on violations; num - number of violations.',default='app',enum='app, bool, num'"` // nolint:lll
 	Verbosity   string   `kong:"help='Verbosity level [${enum}].',default='normal',enum='normal, short'"`
-}
 
-func (lintCommand *LintCommand) Run() error {
-	log.Println("Running linter... TODO", lintCommand)
+	StdinFilename string `kong:"help='File path to report the Dockerfile read from stdin as. The config file is also searched for next to it.'"` // nolint:lll
+}
 
-	if len(lintCommand.Paths) > 1 {
-		log.Warning("Although it's planned, for now only one Dockerfile can be validated at a time.")
-	}
+// Run lints every Dockerfile found in Paths and reports the aggregated results. A Dockerfile that cannot be linted
+// does not stop the others, the first such error is returned after the report.
+func (lintCommand *LintCommand) Run(cli *WhaleLintCLI) error {
+	threshold := RuleSet.ValUnknown
 
-	filePath := lintCommand.Paths[0]
+	if lintCommand.FailOn != "" {
+		severity, err := RuleSet.ParseSeverity(lintCommand.FailOn)
+		if err != nil {
+			return fmt.Errorf("linter | --fail-on | %w", err)
+		}
 
-	// TODO: move to parser
-	/* Parse Dockerfile */
-	stageList, metaArgs, err := Utils.GetDockerfileAst(filePath)
-	if err != nil {
-		return fmt.Errorf("linter | %w", err)
+		threshold = severity
 	}
 
-	if metaArgs != nil {
-		log.Debug("metaArgs |", metaArgs)
-	}
+	ruleValidationResultArray, errList := lintPaths(cli, lintCommand.Paths, lintCommand.Include, lintCommand.Exclude,
+		lintCommand.StdinFilename)
 
-	fileContent, err := Utils.ReadFileContents(filePath)
-	if err != nil {
-		// this is virtually unreachable, so no test case for this branch
-		// could put before the AST parsing, but it would only server test coverage percentage fetish,
-		// while hurting readability marginally.
-		// TODO: rethink error handling here.
-		return fmt.Errorf("linter | %w", err)
-	}
+	if lintCommand.Baseline != "" {
+		baseline, err := Baseline.Load(lintCommand.Baseline)
+		if err != nil {
+			return fmt.Errorf("linter | %w", err)
+		}
 
-	Parser.RawParser.UpdateRawStr(fileContent)
+		staleEntryList := baseline.Filter(ruleValidationResultArray)
+		for _, entry := range staleEntryList {
+			log.Warning("baseline | no longer occurs | ", entry.FilePath, ":", entry.Line, " | ", entry.RuleID, " | ",
+				entry.Message)
+		}
 
-	// Run Linter
-	linter := Linter.Linter{}
-	ruleValidationResultArray := linter.Run(stageList)
+		if len(staleEntryList) > 0 {
+			log.Warning("baseline | ", len(staleEntryList), " stale entries, recreate the baseline to remove them.")
+		}
+	}
 
 	switch lintCommand.Format {
+	case "checkstyle":
+		Report.PrintResultAsCheckstyle(ruleValidationResultArray, os.Stdout)
+	case "github":
+		Report.PrintResultAsGitHubAnnotations(ruleValidationResultArray, os.Stdout)
+	case "gitlab":
+		Report.PrintResultAsGitLabCodeQuality(ruleValidationResultArray, os.Stdout)
 	case "json":
 		Report.PrintResultAsJSON(ruleValidationResultArray, os.Stdout)
+	case "junit":
+		Report.PrintResultAsJUnit(ruleValidationResultArray, os.Stdout)
+	case "sarif":
+		Report.PrintResultAsSARIF(ruleValidationResultArray, os.Stdout)
 	case "summary":
 		var verbosity Report.VerbosityLevel
 
@@ -123,18 +187,312 @@ func (lintCommand *LintCommand) Run() error {
 		Report.PrintSummary(ruleValidationResultArray, os.Stdout, options)
 	}
 
+	if len(errList) > 0 {
+		// the first error is returned, the rest is only logged
+		for _, err := range errList[1:] {
+			log.Error(err)
+		}
+
+		return errList[0]
+	}
+
+	return lintCommand.exitCodeError(ruleValidationResultArray, threshold)
+}
+
+// exitCodeError returns an ExitCodeError according to the return value mode, if the violations require a non-zero
+// exit code, nil otherwise.
+func (lintCommand *LintCommand) exitCodeError(ruleValidationResultArray []RuleSet.RuleValidationResult,
+	threshold RuleSet.Severity) error {
+	violationCount := 0
+
+	for _, result := range ruleValidationResultArray {
+		if result.IsViolated() && result.Severity().IsAtLeast(threshold) {
+			violationCount++
+		}
+	}
+
+	if violationCount == 0 {
+		return nil
+	}
+
+	switch lintCommand.ReturnValue {
+	case "app":
+		if lintCommand.FailOn == "" {
+			return nil
+		}
+	case "num":
+		if violationCount > MaxExitCode {
+			violationCount = MaxExitCode
+		}
+
+		return &ExitCodeError{Code: violationCount}
+	}
+
+	return &ExitCodeError{Code: 1}
+}
+
+// StdinPath is the path, that stands for the Dockerfile read from stdin.
+const StdinPath = "-"
+
+// lintPaths lints every Dockerfile found in pathList. A Dockerfile that cannot be linted does not stop the others.
+// StdinPath is linted from cli.Stdin and reported as stdinFileName, if set.
+func lintPaths(cli *WhaleLintCLI, pathList, includeList, excludeList []string,
+	stdinFileName string) ([]RuleSet.RuleValidationResult, []error) {
+	ruleValidationResultArray := make([]RuleSet.RuleValidationResult, 0)
+
+	filePathList, err := Utils.CollectDockerfiles(pathList, includeList, excludeList)
+	if err != nil {
+		return ruleValidationResultArray, []error{fmt.Errorf("linter | %w", err)}
+	}
+
+	errList := make([]error, 0)
+
+	for _, filePath := range filePathList {
+		var (
+			fileResultArray []RuleSet.RuleValidationResult
+			err             error
+		)
+
+		if filePath == StdinPath {
+			fileResultArray, err = lintStdin(cli.stdin(), stdinFileName, cli.Config)
+		} else {
+			fileResultArray, err = lintFile(filePath, cli.Config)
+		}
+
+		if err != nil {
+			errList = append(errList, err)
+
+			continue
+		}
+
+		ruleValidationResultArray = append(ruleValidationResultArray, fileResultArray...)
+	}
+
+	return ruleValidationResultArray, errList
+}
+
+// lintFile lints a single Dockerfile with its own config and marks the results with the file path.
+func lintFile(filePath string, configPath string) ([]RuleSet.RuleValidationResult, error) {
+	fileContent, err := Utils.ReadFileContents(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("linter | %s | %w", filePath, err)
+	}
+
+	return lintContent(fileContent, filePath, configPath)
+}
+
+// lintStdin lints the Dockerfile read from reader. The results are marked with fileName, or StdinPath if it's empty.
+func lintStdin(reader io.Reader, fileName string, configPath string) ([]RuleSet.RuleValidationResult, error) {
+	if fileName == "" {
+		fileName = StdinPath
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("linter | %s | %w", fileName, err)
+	}
+
+	return lintContent(string(content), fileName, configPath)
+}
+
+// lintContent lints the Dockerfile content with the config of filePath and marks the results with the file path.
+func lintContent(content string, filePath string, configPath string) ([]RuleSet.RuleValidationResult, error) {
+	config, err := Config.Resolve(configPath, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("linter | %w", err)
+	}
+
+	// Run Linter
+	linter := Linter.Linter{Config: config}
+
+	ruleValidationResultArray, err := linter.RunString(content)
+	if err != nil {
+		return nil, fmt.Errorf("linter | %s | %w", filePath, err)
+	}
+
+	for i := range ruleValidationResultArray {
+		ruleValidationResultArray[i].SetFilePath(filePath)
+	}
+
+	return ruleValidationResultArray, nil
+}
+
+type BaselineCommand struct {
+	Create BaselineCreateCommand `kong:"cmd,help='record the current violations into a baseline file.'"`
+}
+
+type BaselineCreateCommand struct {
+	Exclude []string `kong:"help='Glob of files to skip while walking directories, e.g. **/test/**.'"`
+	Include []string `kong:"help='Glob of additional files to lint while walking directories, e.g. *.docker.'"`
+	Output  string   `kong:"help='Baseline file path.',default='.whalelint-baseline.json',type='path'"`
+	Paths   []string `kong:"arg,required,help='Path to Dockerfile or directory to walk.',type:'path'"`
+}
+
+// Run lints every Dockerfile found in Paths and records the violations into the baseline file. The baseline is not
+// written, if any of the Dockerfiles cannot be linted.
+func (baselineCreateCommand *BaselineCreateCommand) Run(cli *WhaleLintCLI) error {
+	ruleValidationResultArray, errList := lintPaths(cli, baselineCreateCommand.Paths, baselineCreateCommand.Include,
+		baselineCreateCommand.Exclude, "")
+	if len(errList) > 0 {
+		for _, err := range errList[1:] {
+			log.Error(err)
+		}
+
+		return errList[0]
+	}
+
+	baseline := Baseline.New(ruleValidationResultArray, baselineCreateCommand.Output)
+
+	if err := baseline.Save(baselineCreateCommand.Output); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.Info("baseline | ", len(baseline.Entries), " violations recorded into ", baselineCreateCommand.Output)
+
+	return nil
+}
+
+var ErrStdinNotSupported = errors.New("reading the Dockerfile from stdin is not supported")
+
+type FixCommand struct {
+	Diff    bool     `kong:"help='Print the changes as a unified diff instead of writing them. Implies --dry-run.'"`
+	DryRun  bool     `kong:"help='Do not write the fixed Dockerfiles, only report the number of fixes.'"`
+	Exclude []string `kong:"help='Glob of files to skip while walking directories, e.g. **/test/**.'"`
+	Include []string `kong:"help='Glob of additional files to fix while walking directories, e.g. *.docker.'"`
+	Paths   []string `kong:"arg,required,help='Path to Dockerfile or directory to walk.',type:'path'"`
+}
+
+// Run fixes every Dockerfile found in Paths. A Dockerfile that cannot be fixed does not stop the others, the first
+// such error is returned at the end.
+func (fixCommand *FixCommand) Run(cli *WhaleLintCLI) error {
+	filePathList, err := Utils.CollectDockerfiles(fixCommand.Paths, fixCommand.Include, fixCommand.Exclude)
+	if err != nil {
+		return fmt.Errorf("fix | %w", err)
+	}
+
+	errList := make([]error, 0)
+
+	for _, filePath := range filePathList {
+		if filePath == StdinPath {
+			errList = append(errList, fmt.Errorf("fix | %w", ErrStdinNotSupported))
+
+			continue
+		}
+
+		if err := fixCommand.fixFile(filePath, cli.Config); err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	if len(errList) > 0 {
+		for _, err := range errList[1:] {
+			log.Error(err)
+		}
+
+		return errList[0]
+	}
+
+	return nil
+}
+
+// fixFile fixes a single Dockerfile with its own config and writes it back, keeping its file mode.
+func (fixCommand *FixCommand) fixFile(filePath string, configPath string) error {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("fix | %w", err)
+	}
+
+	content, err := Utils.ReadFileContents(filePath)
+	if err != nil {
+		return fmt.Errorf("fix | %s | %w", filePath, err)
+	}
+
+	config, err := Config.Resolve(configPath, filePath)
+	if err != nil {
+		return fmt.Errorf("fix | %w", err)
+	}
+
+	linter := Linter.Linter{Config: config}
+
+	fixedContent, fixCount, err := linter.Fix(content)
+	if err != nil {
+		return fmt.Errorf("fix | %s | %w", filePath, err)
+	}
+
+	switch {
+	case fixCommand.Diff:
+		fmt.Print(Utils.UnifiedDiff(filePath, filePath, content, fixedContent))
+	case fixCommand.DryRun:
+		fmt.Printf("%s | %d fixes\n", filePath, fixCount)
+	case fixCount > 0:
+		if err := os.WriteFile(filePath, []byte(fixedContent), fileInfo.Mode().Perm()); err != nil {
+			return fmt.Errorf("fix | %w", err)
+		}
+
+		log.Info("fix | ", filePath, " | ", fixCount, " fixes applied")
+	}
+
 	return nil
 }
 
 type LspCommand struct {
-	Port int `help:"Port number" default:"18888"`
+	Port  int  `help:"Port number" default:"18888"`
+	Stdio bool `help:"Communicate over stdin and stdout instead of TCP."`
 }
 
 // Run starts the Language Server.
-func (lspCommand *LspCommand) Run() error {
-	err := Lsp.Serve(lspCommand.Port)
+func (lspCommand *LspCommand) Run(cli *WhaleLintCLI) error {
+	if lspCommand.Stdio {
+		if err := Lsp.ServeStdio(cli.stdin(), os.Stdout); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		return nil
+	}
+
+	if err := Lsp.Serve(lspCommand.Port); err != nil {
+		return fmt.Errorf("%w", err)
+	}
 
-	return fmt.Errorf("%w", err)
+	return nil
+}
+
+type RulesCommand struct {
+	Format string `kong:"help='Output format [${enum}].',default='table',enum='json, table'"`
+}
+
+// Run lists every rule of the ruleset, sorted by ID.
+func (rulesCommand *RulesCommand) Run() error {
+	ruleList := RuleSet.Get().SortedRuleList()
+
+	switch rulesCommand.Format {
+	case "json":
+		Report.PrintRuleListAsJSON(ruleList, os.Stdout)
+	case "table":
+		Report.PrintRuleListAsTable(ruleList, os.Stdout)
+	}
+
+	return nil
+}
+
+var ErrUnknownRule = errors.New("unknown rule")
+
+type ExplainCommand struct {
+	RuleID string `kong:"arg,help='Rule ID, e.g. RUN006.'"`
+}
+
+// Run prints the rule's definition, description and examples.
+func (explainCommand *ExplainCommand) Run() error {
+	ruleID := strings.ToUpper(explainCommand.RuleID)
+
+	rule := RuleSet.Get().GetRuleByName(ruleID, nil)
+	if rule.ID() == "" {
+		return fmt.Errorf("explain | %w: \"%s\"", ErrUnknownRule, explainCommand.RuleID)
+	}
+
+	Report.PrintRuleExplanation(&rule, RuleSet.GetExamples(ruleID), os.Stdout)
+
+	return nil
 }
 
 type VersionCommand struct{}
@@ -3,8 +3,10 @@ package cli_test
 import (
 	"bytes"
 	"errors"
+	"io"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"testing"
@@ -31,7 +33,11 @@ func generateStdBuffer() StdBuffer {
 }
 
 func generateCLI(args []string) (*kong.Context, StdBuffer, error) {
-	cli := cli.WhaleLintCLI{}
+	return generateCLIWithStdin(args, nil)
+}
+
+func generateCLIWithStdin(args []string, stdin io.Reader) (*kong.Context, StdBuffer, error) {
+	cli := cli.WhaleLintCLI{Stdin: stdin} // nolint:exhaustivestruct
 	parser := kong.Must(&cli, cli.Options()...)
 	stdBuffer := generateStdBuffer()
 	parser.Stdout, parser.Stderr = stdBuffer.stdOut, stdBuffer.stdErr
@@ -70,6 +76,28 @@ func TestVersionCommand_Run(t *testing.T) {
 	assert.Equal(t, "", stdBuffer.stdErr.String())
 }
 
+func TestRulesCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"json", "table"} {
+		ctx, _, err := generateCLI([]string{"rules", "--format", format})
+		assert.NilError(t, err)
+		assert.NilError(t, ctx.Run())
+	}
+}
+
+func TestExplainCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx, _, err := generateCLI([]string{"explain", "run006"})
+	assert.NilError(t, err)
+	assert.NilError(t, ctx.Run())
+
+	ctx, _, err = generateCLI([]string{"explain", "XXX000"})
+	assert.NilError(t, err)
+	assert.Equal(t, true, errors.Is(ctx.Run(), cli.ErrUnknownRule))
+}
+
 func TestCliType_ApplyDefaultCommand(t *testing.T) {
 	t.Parallel()
 
@@ -140,6 +168,15 @@ func TestLspCommand_Run(t *testing.T) {
 	}
 }
 
+func TestLspCommand_RunStdio(t *testing.T) {
+	t.Parallel()
+
+	// the server returns, when the client closes stdin
+	ctx, _, err := generateCLIWithStdin([]string{"lsp", "--stdio"}, strings.NewReader(""))
+	assert.NilError(t, err)
+	assert.NilError(t, ctx.Run())
+}
+
 // nolint:funlen,paralleltest
 func TestLintCommand_Run(t *testing.T) {
 	testCases := []struct {
@@ -182,7 +219,14 @@ func TestLintCommand_Run(t *testing.T) {
 			TmpFileContent: []string{"FROM golang:1.16", "FROM golang:1.16"},
 			Expected:       nil,
 			ExpectedErrStr: "",
-			ExpectedStdout: "Although it's planned, for now only one Dockerfile can be validated at a time.",
+			ExpectedStdout: "",
+		},
+		{
+			Name:           "Call lint with 2 paths, where the 2nd one is non-existing",
+			TmpFileContent: []string{"FROM golang:1.16", ""},
+			Expected:       syscall.ENOENT,
+			ExpectedErrStr: "bogusPath",
+			ExpectedStdout: "",
 		},
 	}
 
@@ -209,13 +253,11 @@ func TestLintCommand_Run(t *testing.T) {
 					assert.NilError(t, errTmpFile)
 
 					os.Args = append(os.Args, tmpFileSlice[i].Name())
+				} else {
+					os.Args = append(os.Args, "bogusPath")
 				}
 			}
 
-			if len(os.Args) == 1 {
-				os.Args = append(os.Args, "bogusPath")
-			}
-
 			ctx, _, err := generateCLI(os.Args)
 			assert.NilError(t, err)
 
@@ -240,3 +282,223 @@ func TestLintCommand_Run(t *testing.T) {
 		})
 	}
 }
+
+// nolint:paralleltest
+func TestLintCommand_RunDirectory(t *testing.T) {
+	rootDir := t.TempDir()
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(rootDir, "api"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(rootDir, "Dockerfile"), []byte("FROM golang:1.16"), 0o600))
+	assert.NilError(t, os.WriteFile(filepath.Join(rootDir, "api", "Dockerfile"), []byte(" "), 0o600))
+
+	// the empty api/Dockerfile cannot be parsed
+	ctx, _, err := generateCLI([]string{"lint", rootDir})
+	assert.NilError(t, err)
+
+	err = ctx.Run()
+	assert.ErrorContains(t, err, filepath.Join(rootDir, "api", "Dockerfile"))
+
+	// unless it's excluded
+	ctx, _, err = generateCLI([]string{"lint", "--exclude", "api/**", rootDir})
+	assert.NilError(t, err)
+
+	err = ctx.Run()
+	assert.NilError(t, err)
+}
+
+// nolint:paralleltest
+func TestLintCommand_RunStdin(t *testing.T) {
+	var exitCodeError *cli.ExitCodeError
+
+	// 2 warnings: STS001 and RUN004
+	stdin := strings.NewReader("FROM golang\nRUN sudo ls")
+
+	ctx, _, err := generateCLIWithStdin([]string{"lint", "--return-value", "num", "-"}, stdin)
+	assert.NilError(t, err)
+
+	err = ctx.Run()
+	assert.Equal(t, true, errors.As(err, &exitCodeError))
+	assert.Equal(t, 2, exitCodeError.Code)
+
+	// the config is searched for next to --stdin-filename
+	rootDir := t.TempDir()
+	configContent := "disable:\n  - RUN004\n"
+	assert.NilError(t, os.WriteFile(filepath.Join(rootDir, ".whalelint.yml"), []byte(configContent), 0o600))
+
+	stdin = strings.NewReader("FROM golang\nRUN sudo ls")
+	args := []string{"lint", "--return-value", "num", "--stdin-filename", filepath.Join(rootDir, "Dockerfile"), "-"}
+
+	ctx, _, err = generateCLIWithStdin(args, stdin)
+	assert.NilError(t, err)
+
+	err = ctx.Run()
+	assert.Equal(t, true, errors.As(err, &exitCodeError))
+	assert.Equal(t, 1, exitCodeError.Code)
+
+	// errors are reported with the stdin file name
+	ctx, _, err = generateCLIWithStdin([]string{"lint", "--stdin-filename", "api/Dockerfile", "-"},
+		strings.NewReader(" "))
+	assert.NilError(t, err)
+	assert.ErrorContains(t, ctx.Run(), "api/Dockerfile")
+}
+
+// nolint:paralleltest
+func TestLintCommand_RunReturnValue(t *testing.T) {
+	dockerfile, err := os.CreateTemp("", "mock-dockerfile.*")
+	assert.NilError(t, err)
+
+	defer os.Remove(dockerfile.Name())
+
+	// 2 warnings: STS001 and RUN004
+	_, err = dockerfile.WriteString("FROM golang\nRUN sudo ls")
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		Name         string
+		Args         []string
+		ExpectedCode int
+	}{
+		{Name: "app", Args: []string{"--return-value", "app"}, ExpectedCode: 0},
+		{Name: "bool", Args: []string{"--return-value", "bool"}, ExpectedCode: 1},
+		{Name: "num", Args: []string{"--return-value", "num"}, ExpectedCode: 2},
+		{Name: "num, fail on error", Args: []string{"--return-value", "num", "--fail-on", "error"}, ExpectedCode: 0},
+		{Name: "app, fail on warning", Args: []string{"--fail-on", "Warning"}, ExpectedCode: 1},
+		{Name: "bool, fail on info", Args: []string{"--return-value", "bool", "--fail-on", "info"}, ExpectedCode: 1},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx, _, err := generateCLI(append(append([]string{"lint"}, testCase.Args...), dockerfile.Name()))
+			assert.NilError(t, err)
+
+			err = ctx.Run()
+
+			if testCase.ExpectedCode == 0 {
+				assert.NilError(t, err)
+
+				return
+			}
+
+			var exitCodeError *cli.ExitCodeError
+
+			assert.Equal(t, true, errors.As(err, &exitCodeError))
+			assert.Equal(t, testCase.ExpectedCode, exitCodeError.Code)
+		})
+	}
+
+	// invalid severity
+	ctx, _, err := generateCLI([]string{"lint", "--fail-on", "fatal", dockerfile.Name()})
+	assert.NilError(t, err)
+
+	err = ctx.Run()
+	assert.ErrorContains(t, err, "invalid severity")
+}
+
+// nolint:paralleltest
+func TestBaselineCreateCommand_Run(t *testing.T) {
+	rootDir := t.TempDir()
+	dockerfilePath := filepath.Join(rootDir, "Dockerfile")
+	baselinePath := filepath.Join(rootDir, "baseline.json")
+
+	// 2 warnings: STS001 and RUN004
+	assert.NilError(t, os.WriteFile(dockerfilePath, []byte("FROM golang\nRUN sudo ls"), 0o600))
+
+	ctx, _, err := generateCLI([]string{"baseline", "create", "--output", baselinePath, dockerfilePath})
+	assert.NilError(t, err)
+	assert.NilError(t, ctx.Run())
+
+	// recorded violations are not reported
+	ctx, _, err = generateCLI([]string{"lint", "--return-value", "num", "--baseline", baselinePath, dockerfilePath})
+	assert.NilError(t, err)
+	assert.NilError(t, ctx.Run())
+
+	// new ones are, even if the old ones have moved
+	assert.NilError(t, os.WriteFile(dockerfilePath, []byte("FROM golang\n\nRUN sudo ls\nRUN sudo ls"), 0o600))
+
+	ctx, _, err = generateCLI([]string{"lint", "--return-value", "num", "--baseline", baselinePath, dockerfilePath})
+	assert.NilError(t, err)
+
+	var exitCodeError *cli.ExitCodeError
+
+	err = ctx.Run()
+	assert.Equal(t, true, errors.As(err, &exitCodeError))
+	assert.Equal(t, 1, exitCodeError.Code)
+
+	// missing baseline
+	ctx, _, err = generateCLI([]string{"lint", "--baseline", baselinePath + ".missing", dockerfilePath})
+	assert.NilError(t, err)
+	assert.Equal(t, true, TestHelper.CheckForErrorRecursively(t, ctx.Run(), syscall.ENOENT))
+}
+
+// nolint:paralleltest
+func TestFixCommand_Run(t *testing.T) {
+	rootDir := t.TempDir()
+	dockerfilePath := filepath.Join(rootDir, "Dockerfile")
+	content := "FROM golang\nWORKDIR app\nCMD go run main.go\n"
+
+	assert.NilError(t, os.WriteFile(dockerfilePath, []byte(content), 0o640))
+
+	readDockerfile := func() string {
+		fileContent, err := os.ReadFile(dockerfilePath)
+		assert.NilError(t, err)
+
+		return string(fileContent)
+	}
+
+	// --dry-run and --diff leave the file as is
+	for _, flag := range []string{"--dry-run", "--diff"} {
+		ctx, _, err := generateCLI([]string{"fix", flag, dockerfilePath})
+		assert.NilError(t, err)
+		assert.NilError(t, ctx.Run())
+		assert.Equal(t, content, readDockerfile())
+	}
+
+	ctx, _, err := generateCLI([]string{"fix", rootDir})
+	assert.NilError(t, err)
+	assert.NilError(t, ctx.Run())
+	assert.Equal(t, "FROM golang\nWORKDIR /app\nCMD [\"go\", \"run\", \"main.go\"]\n", readDockerfile())
+
+	fileInfo, err := os.Stat(dockerfilePath)
+	assert.NilError(t, err)
+	assert.Equal(t, os.FileMode(0o640), fileInfo.Mode().Perm())
+
+	// stdin
+	ctx, _, err = generateCLIWithStdin([]string{"fix", "-"}, strings.NewReader(content))
+	assert.NilError(t, err)
+	assert.Equal(t, true, errors.Is(ctx.Run(), cli.ErrStdinNotSupported))
+}
+
+// nolint:paralleltest
+func TestLintCommand_RunWithConfig(t *testing.T) {
+	dockerfile, err := os.CreateTemp("", "mock-dockerfile.*")
+	assert.NilError(t, err)
+
+	defer os.Remove(dockerfile.Name())
+
+	_, err = dockerfile.WriteString("FROM golang:1.16")
+	assert.NilError(t, err)
+
+	config, err := os.CreateTemp("", "mock-config.*.yml")
+	assert.NilError(t, err)
+
+	defer os.Remove(config.Name())
+
+	_, err = config.WriteString("rules:\n  RUN009:\n    severity: Fatal\n")
+	assert.NilError(t, err)
+
+	// valid Dockerfile, invalid config
+	ctx, _, err := generateCLI([]string{"--config", config.Name(), "lint", dockerfile.Name()})
+	assert.NilError(t, err)
+
+	err = ctx.Run()
+	assert.ErrorContains(t, err, "invalid severity")
+
+	// non-existing config
+	ctx, _, err = generateCLI([]string{"--config", config.Name() + ".missing", "lint", dockerfile.Name()})
+	assert.NilError(t, err)
+
+	err = ctx.Run()
+	assert.Equal(t, true, TestHelper.CheckForErrorRecursively(t, err, syscall.ENOENT))
+}
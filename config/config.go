@@ -0,0 +1,276 @@
+// Package config provides the WhaleLint config file support.
+//
+// The config file is either passed explicitly or auto-discovered by walking up the directory tree from the linted
+// Dockerfile. It can disable rules, re-map their severity and set per-rule options, e.g.
+//
+//	disable:
+//	  - RUN002
+//	rules:
+//	  RUN009:
+//	    severity: Error
+//	  RUN001:
+//	    options:
+//	      additionalCommands: [htop]
+//
+// As JSON is a subset of YAML, the same structure can be written in JSON as well.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// FileNameList is the list of config file names that are looked for during auto-discovery, in order of precedence.
+var FileNameList = []string{".whalelint.yml", ".whalelint.yaml", ".whalelint.json"} // nolint:gochecknoglobals
+
+// Config represents a WhaleLint config file.
+type Config struct {
+	Disable []string              `yaml:"disable"`
+	Rules   map[string]RuleConfig `yaml:"rules"`
+
+	path string
+}
+
+// RuleConfig holds the settings of a single rule.
+type RuleConfig struct {
+	Severity string              `yaml:"severity"`
+	Options  RuleSet.RuleOptions `yaml:"options"`
+}
+
+// Default returns an empty config, i.e. every rule is enabled with its default severity.
+func Default() *Config {
+	return &Config{
+		Disable: []string{},
+		Rules:   map[string]RuleConfig{},
+		path:    "",
+	}
+}
+
+// Load reads and validates the config file at filePath.
+func Load(filePath string) (*Config, error) {
+	fileContent, err := Utils.ReadFileContents(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("config | %w", err)
+	}
+
+	config, err := Parse(fileContent)
+	if err != nil {
+		return nil, fmt.Errorf("config | %s | %w", filePath, err)
+	}
+
+	config.path = filePath
+
+	return config, nil
+}
+
+// Parse parses and validates a config from its YAML or JSON string representation.
+func Parse(str string) (*Config, error) {
+	config := Default()
+
+	if err := yaml.Unmarshal([]byte(str), config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	config.normalize()
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Discover looks for a config file in the directory of the Dockerfile and then in each of its parent directories.
+// It returns an empty string if there is none.
+func Discover(dockerfilePath string) (string, error) {
+	absPath, err := filepath.Abs(dockerfilePath)
+	if err != nil {
+		return "", fmt.Errorf("config | %w", err)
+	}
+
+	for dir := filepath.Dir(absPath); ; dir = filepath.Dir(dir) {
+		for _, fileName := range FileNameList {
+			candidate := filepath.Join(dir, fileName)
+			if fileInfo, err := os.Stat(candidate); err == nil && !fileInfo.IsDir() {
+				return candidate, nil
+			}
+		}
+
+		if dir == filepath.Dir(dir) { // reached the root
+			return "", nil
+		}
+	}
+}
+
+// Resolve returns the config for the Dockerfile at dockerfilePath. The explicitly given configPath takes precedence,
+// otherwise the config file is auto-discovered. If there is none, the default config is returned.
+func Resolve(configPath, dockerfilePath string) (*Config, error) {
+	if configPath == "" {
+		discoveredPath, err := Discover(dockerfilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if discoveredPath == "" {
+			return Default(), nil
+		}
+
+		configPath = discoveredPath
+	}
+
+	log.Debug("Using config file ", configPath)
+
+	return Load(configPath)
+}
+
+// Validate checks the severity values and warns about unknown rule IDs.
+func (config *Config) Validate() error {
+	for ruleID, ruleConfig := range config.Rules {
+		warnOnUnknownRule(ruleID)
+
+		if ruleConfig.Severity == "" {
+			continue
+		}
+
+		if _, err := RuleSet.ParseSeverity(ruleConfig.Severity); err != nil {
+			return fmt.Errorf("rule %s | %w", ruleID, err)
+		}
+	}
+
+	for _, ruleID := range config.Disable {
+		warnOnUnknownRule(ruleID)
+	}
+
+	return nil
+}
+
+// Path returns the path of the file that the config was loaded from. It's empty for the default config.
+func (config *Config) Path() string {
+	if config == nil {
+		return ""
+	}
+
+	return config.path
+}
+
+// IsRuleEnabled tells whether the rule with ruleID should be validated.
+func (config *Config) IsRuleEnabled(ruleID string) bool {
+	if config == nil {
+		return true
+	}
+
+	return !Utils.EqualsEither(strings.ToUpper(ruleID), config.Disable)
+}
+
+// Severity returns the severity override of a rule, if there is any.
+func (config *Config) Severity(ruleID string) (RuleSet.Severity, bool) {
+	if config == nil {
+		return RuleSet.ValUnknown, false
+	}
+
+	ruleConfig, ok := config.Rules[strings.ToUpper(ruleID)]
+	if !ok || ruleConfig.Severity == "" {
+		return RuleSet.ValUnknown, false
+	}
+
+	severity, err := RuleSet.ParseSeverity(ruleConfig.Severity)
+	if err != nil {
+		return RuleSet.ValUnknown, false
+	}
+
+	return severity, true
+}
+
+// RuleOptions returns the options of a rule. It's nil, if there is none.
+func (config *Config) RuleOptions(ruleID string) RuleSet.RuleOptions {
+	if config == nil {
+		return nil
+	}
+
+	return config.Rules[strings.ToUpper(ruleID)].Options
+}
+
+// ApplyTo returns the rules that are enabled by the config, with their severity and options updated.
+func (config *Config) ApplyTo(ruleList []RuleSet.Rule) []RuleSet.Rule {
+	result := make([]RuleSet.Rule, 0, len(ruleList))
+
+	for _, rule := range ruleList {
+		if !config.IsRuleEnabled(rule.ID()) {
+			continue
+		}
+
+		if severity, ok := config.Severity(rule.ID()); ok {
+			rule.SetSeverity(severity)
+		}
+
+		rule.SetOptions(config.RuleOptions(rule.ID()))
+
+		result = append(result, rule)
+	}
+
+	return result
+}
+
+// Merge returns a new config, that is config overridden by other, e.g. a config file by the editor settings. The
+// disabled rules are combined, while the severity and the options of a rule in other take precedence.
+func (config *Config) Merge(other *Config) *Config {
+	result := Default()
+
+	for _, source := range []*Config{config, other} {
+		if source == nil {
+			continue
+		}
+
+		for _, ruleID := range source.Disable {
+			if !Utils.EqualsEither(ruleID, result.Disable) {
+				result.Disable = append(result.Disable, ruleID)
+			}
+		}
+
+		for ruleID, ruleConfig := range source.Rules {
+			mergedRuleConfig := result.Rules[ruleID]
+
+			if ruleConfig.Severity != "" {
+				mergedRuleConfig.Severity = ruleConfig.Severity
+			}
+
+			if ruleConfig.Options != nil {
+				mergedRuleConfig.Options = ruleConfig.Options
+			}
+
+			result.Rules[ruleID] = mergedRuleConfig
+		}
+	}
+
+	result.path = config.Path()
+
+	return result
+}
+
+// normalize converts the rule IDs to uppercase, so they can be written in any case in the config file.
+func (config *Config) normalize() {
+	for i, ruleID := range config.Disable {
+		config.Disable[i] = strings.ToUpper(ruleID)
+	}
+
+	ruleMap := make(map[string]RuleConfig, len(config.Rules))
+	for ruleID, ruleConfig := range config.Rules {
+		ruleMap[strings.ToUpper(ruleID)] = ruleConfig
+	}
+
+	config.Rules = ruleMap
+}
+
+func warnOnUnknownRule(ruleID string) {
+	if rule := RuleSet.Get().GetRuleByName(ruleID, nil); rule.ID() == "" {
+		log.Warning("config | unknown rule ", ruleID)
+	}
+}
@@ -0,0 +1,201 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	Config "github.com/cremindes/whalelint/config"
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name            string
+		ConfigStr       string
+		IsValid         bool
+		DisabledRuleID  string
+		SeverityRuleID  string
+		Severity        RuleSet.Severity
+		OptionsRuleID   string
+		OptionsExpected []string
+	}{
+		{
+			Name: "YAML config.",
+			ConfigStr: "disable:\n  - run002\n" +
+				"rules:\n  RUN009:\n    severity: error\n  RUN001:\n    options:\n      additionalCommands: [htop]\n",
+			IsValid:         true,
+			DisabledRuleID:  "RUN002",
+			SeverityRuleID:  "RUN009",
+			Severity:        RuleSet.ValError,
+			OptionsRuleID:   "RUN001",
+			OptionsExpected: []string{"htop"},
+		},
+		{
+			Name: "JSON config.",
+			ConfigStr: `{"disable": ["STS001"], "rules": {"run008": {"severity": "Info"}, ` +
+				`"RUN001": {"options": {"additionalCommands": ["htop", "lsof"]}}}}`,
+			IsValid:         true,
+			DisabledRuleID:  "STS001",
+			SeverityRuleID:  "RUN008",
+			Severity:        RuleSet.ValInfo,
+			OptionsRuleID:   "RUN001",
+			OptionsExpected: []string{"htop", "lsof"},
+		},
+		{
+			Name:      "Invalid severity.",
+			ConfigStr: "rules:\n  RUN009:\n    severity: fatal\n",
+			IsValid:   false,
+		},
+		{
+			Name:      "Invalid YAML.",
+			ConfigStr: "disable: [RUN002",
+			IsValid:   false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := Config.Parse(testCase.ConfigStr)
+			if !testCase.IsValid {
+				assert.NotNil(t, err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.False(t, config.IsRuleEnabled(testCase.DisabledRuleID))
+			assert.True(t, config.IsRuleEnabled(testCase.SeverityRuleID))
+
+			severity, ok := config.Severity(testCase.SeverityRuleID)
+			assert.True(t, ok)
+			assert.Equal(t, testCase.Severity, severity)
+
+			_, ok = config.Severity(testCase.OptionsRuleID)
+			assert.False(t, ok)
+
+			assert.Equal(t, testCase.OptionsExpected,
+				config.RuleOptions(testCase.OptionsRuleID).StringSlice("additionalCommands"))
+		})
+	}
+}
+
+func TestConfig_ApplyTo(t *testing.T) {
+	t.Parallel()
+
+	config, err := Config.Parse("disable: [RUN002]\nrules:\n  RUN009:\n    severity: Error\n")
+	assert.Nil(t, err)
+
+	ruleList := []RuleSet.Rule{
+		RuleSet.Get().GetRuleByName("RUN002", nil),
+		RuleSet.Get().GetRuleByName("RUN009", nil),
+		RuleSet.Get().GetRuleByName("RUN010", nil),
+	}
+
+	result := config.ApplyTo(ruleList)
+
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, "RUN009", result[0].ID())
+	assert.Equal(t, RuleSet.ValError, result[0].Severity())
+	assert.Equal(t, "RUN010", result[1].ID())
+	assert.Equal(t, RuleSet.ValWarning, result[1].Severity())
+
+	// the registered rules are left intact
+	registeredRule := RuleSet.Get().GetRuleByName("RUN009", nil)
+	assert.Equal(t, RuleSet.ValWarning, registeredRule.Severity())
+}
+
+func TestConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	fileConfig, err := Config.Parse("disable: [RUN002]\nrules:\n  RUN009:\n    severity: Error\n" +
+		"  RUN001:\n    options:\n      additionalCommands: [htop]\n")
+	assert.Nil(t, err)
+
+	settings, err := Config.Parse(`{"disable": ["run002", "STS001"], "rules": {"RUN009": {"severity": "Info"}}}`)
+	assert.Nil(t, err)
+
+	merged := fileConfig.Merge(settings)
+
+	assert.Equal(t, []string{"RUN002", "STS001"}, merged.Disable)
+	assert.Equal(t, map[string]Config.RuleConfig{
+		"RUN001": {Severity: "", Options: RuleSet.RuleOptions{"additionalCommands": []interface{}{"htop"}}},
+		"RUN009": {Severity: "Info", Options: nil},
+	}, merged.Rules)
+
+	// the merged configs are left intact
+	assert.Equal(t, []string{"RUN002"}, fileConfig.Disable)
+
+	// nil is the default config
+	var nilConfig *Config.Config
+
+	assert.Equal(t, settings.Disable, nilConfig.Merge(settings).Disable)
+	assert.Equal(t, fileConfig.Rules, fileConfig.Merge(nil).Rules)
+}
+
+func TestConfig_NilIsDefault(t *testing.T) {
+	t.Parallel()
+
+	var config *Config.Config
+
+	ruleList := []RuleSet.Rule{RuleSet.Get().GetRuleByName("RUN002", nil)}
+
+	assert.True(t, config.IsRuleEnabled("RUN002"))
+	assert.Equal(t, "", config.Path())
+	result := config.ApplyTo(ruleList)
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, "RUN002", result[0].ID())
+}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	projectDir := filepath.Join(rootDir, "project")
+	dockerfileDir := filepath.Join(projectDir, "services", "api")
+	configPath := filepath.Join(projectDir, ".whalelint.yml")
+
+	assert.Nil(t, os.MkdirAll(dockerfileDir, 0o755))
+
+	// no config file yet
+	discoveredPath, err := Config.Discover(filepath.Join(dockerfileDir, "Dockerfile"))
+	assert.Nil(t, err)
+	assert.Equal(t, "", discoveredPath)
+
+	config, err := Config.Resolve("", filepath.Join(dockerfileDir, "Dockerfile"))
+	assert.Nil(t, err)
+	assert.Equal(t, "", config.Path())
+
+	// config file in a parent directory
+	assert.Nil(t, os.WriteFile(configPath, []byte("disable: [RUN002]\n"), 0o600))
+
+	discoveredPath, err = Config.Discover(filepath.Join(dockerfileDir, "Dockerfile"))
+	assert.Nil(t, err)
+	assert.Equal(t, configPath, discoveredPath)
+
+	config, err = Config.Resolve("", filepath.Join(dockerfileDir, "Dockerfile"))
+	assert.Nil(t, err)
+	assert.Equal(t, configPath, config.Path())
+	assert.False(t, config.IsRuleEnabled("RUN002"))
+
+	// explicit config path takes precedence
+	explicitConfigPath := filepath.Join(rootDir, "explicit.yml")
+	assert.Nil(t, os.WriteFile(explicitConfigPath, []byte("disable: [RUN009]\n"), 0o600))
+
+	config, err = Config.Resolve(explicitConfigPath, filepath.Join(dockerfileDir, "Dockerfile"))
+	assert.Nil(t, err)
+	assert.True(t, config.IsRuleEnabled("RUN002"))
+	assert.False(t, config.IsRuleEnabled("RUN009"))
+
+	// missing explicit config file
+	_, err = Config.Resolve(filepath.Join(rootDir, "missing.yml"), filepath.Join(dockerfileDir, "Dockerfile"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,43 @@
+package linter
+
+import (
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// MaxFixPassCount limits the lint and fix iterations of Fix, in case the fixes of two rules keep undoing each other.
+const MaxFixPassCount = 10
+
+// Fix applies the autofixes of the violated rules to the Dockerfile content. Each pass applies the non-overlapping
+// edits, then the result is re-linted, until there is nothing left to fix. Returns the fixed content and the number of
+// applied edits.
+func (l *Linter) Fix(content string) (string, int, error) {
+	fixCount := 0
+
+	for pass := 0; pass < MaxFixPassCount; pass++ {
+		ruleValidationResultArray, err := l.RunString(content)
+		if err != nil {
+			return content, fixCount, err
+		}
+
+		editList := make([]RuleSet.TextEdit, 0)
+
+		for i := range ruleValidationResultArray {
+			result := &ruleValidationResultArray[i]
+			if !result.IsViolated() || !result.Rule().IsFixable() {
+				continue
+			}
+
+			editList = append(editList, result.Rule().Fix(result, content)...)
+		}
+
+		fixedContent, appliedCount := RuleSet.ApplyTextEdits(content, editList)
+		if appliedCount == 0 {
+			break
+		}
+
+		content = fixedContent
+		fixCount += appliedCount
+	}
+
+	return content, fixCount, nil
+}
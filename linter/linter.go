@@ -1,119 +1,270 @@
 package linter
 
 import (
+	"fmt"
+	"io"
+	"strings"
+
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
-	log "github.com/sirupsen/logrus"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
 
+	Config "github.com/cremindes/whalelint/config"
 	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Parser "github.com/cremindes/whalelint/parser"
+	Utils "github.com/cremindes/whalelint/utils"
 )
 
-var MainLinter Linter // nolint:gochecknoglobals
+// Linter validates Dockerfile AST elements against the ruleset, honoring its Config.
+// A nil Config means every rule is enabled with its default severity.
+//
+// A Linter only reads its Config, so it can lint many Dockerfiles concurrently, as each run has its own context.
+type Linter struct {
+	Config *Config.Config
+}
+
+// RunString parses the Dockerfile content and validates it, see Run. This lets editors and scripts lint unsaved
+// buffers and generated Dockerfiles without writing them into a file first.
+func (l *Linter) RunString(content string) ([]RuleSet.RuleValidationResult, error) {
+	return l.RunSource("", content)
+}
+
+// RunSource parses the Dockerfile source of fileName and validates it, see Run.
+func (l *Linter) RunSource(fileName string, source string) ([]RuleSet.RuleValidationResult, error) {
+	stageList, metaArgs, err := Utils.ParseDockerfileAst(strings.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return l.Run(RuleSet.NewContext(fileName, source).WithMetaArgs(metaArgs), stageList), nil
+}
+
+// RunReader reads the Dockerfile content from reader, e.g. os.Stdin, and validates it, see RunString.
+func (l *Linter) RunReader(reader io.Reader) ([]RuleSet.RuleValidationResult, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
 
-// Linter
-// TODO: add config Config.
-type Linter struct{}
+	return l.RunString(string(content))
+}
 
-// nolint:nestif, funlen, gocognit
-/* Validate each Dockerfile AST entry against rules in ruleset package. */
-func (l *Linter) Run(stageList []instructions.Stage) []RuleSet.RuleValidationResult {
+// Run validates each Dockerfile AST node, i.e. the stage list, the meta ARGs of ctx, the stages and their instructions,
+// against the rules of its kind in the ruleset package. The rules locate the violations in the raw source of ctx, that
+// stageList is parsed from, and the inline suppression directives are read from it too. The instructions of the raw
+// source, that buildkit cannot parse, are left out of stageList, so they are validated separately.
+func (l *Linter) Run(ctx *RuleSet.Context, stageList []instructions.Stage) []RuleSet.RuleValidationResult {
 	ruleValidationResultArray := make([]RuleSet.RuleValidationResult, 0)
 
-	if len(stageList) == 0 {
+	invalidInstructionList := make([]*Parser.InvalidInstruction, 0)
+	if ctx.HasSource() {
+		invalidInstructionList = Parser.ParseInvalidInstructionList(ctx.Source())
+	}
+
+	if len(stageList) == 0 && len(invalidInstructionList) == 0 {
 		return ruleValidationResultArray
 	}
 
-	// Call Dockerfile AST level validators
-	stageListRuleSet := RuleSet.GetRulesForAstElement(stageList)
-	for _, rule := range stageListRuleSet {
-		validationResult := rule.Validate(stageList)
-		ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
+	if len(stageList) > 0 {
+		ruleValidationResultArray = append(ruleValidationResultArray, l.validate(ctx, stageList)...)
+	}
+
+	metaArgList := ctx.MetaArgs()
+	for i := range metaArgList {
+		ruleValidationResultArray = append(ruleValidationResultArray, l.validate(ctx, &metaArgList[i])...)
 	}
 
-	// Get rules for stage elements
-	stageRuleSet := RuleSet.GetRulesForAstElement(stageList[0])
-	// Go over the stages
 	for _, stage := range stageList {
-		// Call Dockerfile stage level validators
-		for _, rule := range stageRuleSet {
-			validationResult := rule.Validate(stage)
-			ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-		}
+		ruleValidationResultArray = append(ruleValidationResultArray, l.validate(ctx, stage)...)
 
 		argMap := make(map[string]string)
 
 		for _, command := range stage.Commands {
-			// Call Dockerfile Command level validators, but first filter them by type
-			if argCommand, ok := command.(*instructions.ArgCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(argCommand) {
-					validationResult := rule.Validate(argCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-
-				// TODO: better solution than this workaround
-				// register stage arg value
-				if argCommand.Args[0].Value != nil {
-					value := *argCommand.Args[0].Value
-					argMap[argCommand.Args[0].Key] = value[1 : len(value)-1]
-				}
-			} else if cmdCommand, ok := command.(*instructions.CmdCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(cmdCommand) {
-					validationResult := rule.Validate(cmdCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if copyCommand, ok := command.(*instructions.CopyCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(copyCommand) {
-					validationResult := rule.Validate(copyCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if entrypointCommand, ok := command.(*instructions.EntrypointCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(entrypointCommand) {
-					validationResult := rule.Validate(entrypointCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if exposeCommand, ok := command.(*instructions.ExposeCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(exposeCommand) {
-					ResolveSliceFromArgMap(exposeCommand.Ports, argMap)
-
-					validationResult := rule.Validate(exposeCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if labelCommand, ok := command.(*instructions.LabelCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(labelCommand) {
-					validationResult := rule.Validate(labelCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if runCommand, ok := command.(*instructions.RunCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(runCommand) {
-					validationResult := rule.Validate(runCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if shellCommand, ok := command.(*instructions.ShellCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(shellCommand) {
-					validationResult := rule.Validate(shellCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if userCommand, ok := command.(*instructions.UserCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(userCommand) {
-					validationResult := rule.Validate(userCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if workdirCommand, ok := command.(*instructions.WorkdirCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(workdirCommand) {
-					validationResult := rule.Validate(workdirCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else if maintainerCommand, ok := command.(*instructions.MaintainerCommand); ok {
-				for _, rule := range RuleSet.GetRulesForAstElement(maintainerCommand) {
-					validationResult := rule.Validate(maintainerCommand)
-					ruleValidationResultArray = append(ruleValidationResultArray, validationResult)
-				}
-			} else {
-				log.Error("Unhandled Command!")
+			switch command := command.(type) {
+			case *instructions.ArgCommand:
+				registerArgValues(command, argMap)
+			case *instructions.ExposeCommand:
+				ResolveSliceFromArgMap(command.Ports, argMap)
+			}
+
+			ruleValidationResultArray = append(ruleValidationResultArray, l.validate(ctx, command)...)
+
+			if onbuildCommand, ok := command.(*instructions.OnbuildCommand); ok {
+				ruleValidationResultArray = append(ruleValidationResultArray, l.validateOnbuildTrigger(ctx, onbuildCommand)...)
 			}
 		}
 	}
 
-	return ruleValidationResultArray
+	for _, invalidInstruction := range invalidInstructionList {
+		ruleValidationResultArray = append(ruleValidationResultArray, l.validate(ctx, invalidInstruction)...)
+	}
+
+	instructionList := getInstructionList(metaArgList, stageList, invalidInstructionList)
+	setInstructionSourceCode(instructionList, ruleValidationResultArray)
+
+	return l.applySuppressions(ctx, instructionList, ruleValidationResultArray)
+}
+
+// validate validates the Dockerfile AST node against the rules of its kind in ctx.
+func (l *Linter) validate(ctx *RuleSet.Context, node interface{}) []RuleSet.RuleValidationResult {
+	ruleList := l.rulesFor(node)
+	resultList := make([]RuleSet.RuleValidationResult, 0, len(ruleList))
+
+	for i := range ruleList {
+		resultList = append(resultList, ruleList[i].Validate(node, ctx))
+	}
+
+	return resultList
+}
+
+// registerArgValues stores the default values of the ARG instruction in argMap, without the surrounding quotes.
+func registerArgValues(argCommand *instructions.ArgCommand, argMap map[string]string) {
+	for _, arg := range argCommand.Args {
+		if arg.Value == nil {
+			continue
+		}
+
+		value := *arg.Value
+		if len(value) >= 2 && strings.ContainsAny(value[:1], `"'`) && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		argMap[arg.Key] = value
+	}
+}
+
+// instruction is the whole line range and the source code of a Dockerfile instruction, including FROM.
+type instruction struct {
+	lineRange  parser.Range
+	sourceCode string
+}
+
+// getInstructionList returns the meta ARGs, i.e. the ones before the first FROM, and the instructions of the stages
+// in order of appearance, followed by the invalid instructions.
+func getInstructionList(metaArgList []instructions.ArgCommand, stageList []instructions.Stage,
+	invalidInstructionList []*Parser.InvalidInstruction) []instruction {
+	instructionList := make([]instruction, 0)
+
+	for i := range metaArgList {
+		instructionList = appendInstruction(instructionList, metaArgList[i].Location(), metaArgList[i].String())
+	}
+
+	for _, stage := range stageList {
+		instructionList = appendInstruction(instructionList, stage.Location, stage.SourceCode)
+
+		for _, command := range stage.Commands {
+			sourceCode := ""
+			if stringer, ok := command.(fmt.Stringer); ok { // every buildkit command returns its source code
+				sourceCode = stringer.String()
+			}
+
+			instructionList = appendInstruction(instructionList, command.Location(), sourceCode)
+		}
+	}
+
+	for _, invalidInstruction := range invalidInstructionList {
+		instructionList = appendInstruction(instructionList, invalidInstruction.Location(),
+			invalidInstruction.Node.Original)
+	}
+
+	return instructionList
+}
+
+// appendInstruction appends an instruction, whose location can span over multiple ranges.
+func appendInstruction(instructionList []instruction, location []parser.Range, sourceCode string) []instruction {
+	if len(location) == 0 {
+		return instructionList
+	}
+
+	return append(instructionList, instruction{
+		lineRange: parser.Range{
+			Start: location[0].Start,
+			End:   location[len(location)-1].End,
+		},
+		sourceCode: sourceCode,
+	})
+}
+
+// setInstructionSourceCode stores the source code of the enclosing instruction on the results, so they can be
+// identified independently of their line numbers, e.g. by fingerprints. The secrets found by any rule of an instruction
+// are masked in all of its results.
+func setInstructionSourceCode(instructionList []instruction, resultList []RuleSet.RuleValidationResult) {
+	instructionIndexList := make([]int, len(resultList))
+	// the secrets found by the rules per instruction index, masked in every result of the instruction
+	secretListMap := make(map[int][]string)
+
+	for i := range resultList {
+		instructionIndexList[i] = instructionIndexOf(instructionList, resultList[i].LocationRange.Start())
+
+		if secret := resultList[i].Secret(); secret != "" && instructionIndexList[i] != -1 {
+			secretListMap[instructionIndexList[i]] = append(secretListMap[instructionIndexList[i]], secret)
+		}
+	}
+
+	for i, instructionIndex := range instructionIndexList {
+		if instructionIndex != -1 {
+			resultList[i].SetInstruction(instructionList[instructionIndex].sourceCode, secretListMap[instructionIndex]...)
+		}
+	}
+}
+
+// instructionIndexOf returns the index of the instruction, that spans the line of start, or -1.
+func instructionIndexOf(instructionList []instruction, start *RuleSet.Location) int {
+	if start == nil {
+		return -1
+	}
+
+	for i, instruction := range instructionList {
+		if instruction.lineRange.Start.Line <= start.LineNumber() && start.LineNumber() <= instruction.lineRange.End.Line {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// applySuppressions resolves the inline suppression directives of the Dockerfile, clears the violation of the
+// suppressed results and validates the suppressions themselves, so the unused ones get reported.
+func (l *Linter) applySuppressions(ctx *RuleSet.Context, instructionList []instruction,
+	resultList []RuleSet.RuleValidationResult) []RuleSet.RuleValidationResult {
+	if !ctx.HasSource() {
+		return resultList
+	}
+
+	instructionRangeList := make([]parser.Range, len(instructionList))
+	for i, instruction := range instructionList {
+		instructionRangeList[i] = instruction.lineRange
+	}
+
+	suppressionList := Parser.ParseSuppressionList(ctx.Source(), instructionRangeList)
+
+	for i := range resultList {
+		result := &resultList[i]
+		if !result.IsViolated() || result.LocationRange.Start() == nil {
+			continue
+		}
+
+		for _, suppression := range suppressionList {
+			// every matching suppression is marked as used, so don't stop at the first one
+			if suppression.Suppresses(result.RuleID(), result.LocationRange.Start().LineNumber()) {
+				result.SetViolated(false, RuleSet.FORCE)
+			}
+		}
+	}
+
+	for _, suppression := range suppressionList {
+		for _, validationResult := range l.validate(ctx, suppression) {
+			validationResult.SetInstruction(suppression.Text)
+			resultList = append(resultList, validationResult)
+		}
+	}
+
+	return resultList
+}
+
+// rulesFor returns the rules that the given Dockerfile AST element needs to be validated against, filtered and
+// adjusted according to the linter's config.
+func (l *Linter) rulesFor(astElement interface{}) []RuleSet.Rule {
+	return l.Config.ApplyTo(RuleSet.GetRulesForAstElement(astElement))
 }
 
 func ResolveSliceFromArgMap(strSlice []string, argMap map[string]string) {
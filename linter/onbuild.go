@@ -0,0 +1,62 @@
+package linter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	log "github.com/sirupsen/logrus"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Parser "github.com/cremindes/whalelint/parser"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var errNoTrigger = errors.New("ONBUILD has no trigger instruction")
+
+// validateOnbuildTrigger validates the trigger instruction of the ONBUILD command, as it's run in the child image.
+// A trigger, that buildkit cannot build a command from, is validated as an invalid instruction.
+func (l *Linter) validateOnbuildTrigger(ctx *RuleSet.Context,
+	onbuildCommand *instructions.OnbuildCommand) []RuleSet.RuleValidationResult {
+	node, err := parseOnbuildTrigger(onbuildCommand)
+	if err != nil {
+		log.Debug("Cannot parse the ONBUILD trigger.", err)
+
+		return nil
+	}
+
+	instruction, err := instructions.ParseInstruction(node)
+	if err != nil {
+		return l.validate(ctx, &Parser.InvalidInstruction{Node: node, Err: err})
+	}
+
+	command, ok := instruction.(instructions.Command)
+	if !ok {
+		return nil
+	}
+
+	return l.validate(ctx, command)
+}
+
+// parseOnbuildTrigger parses the trigger instruction of the ONBUILD command, e.g. RUN make in ONBUILD RUN make,
+// located at the ONBUILD command, so it can be validated like the instructions of the stage.
+func parseOnbuildTrigger(onbuildCommand *instructions.OnbuildCommand) (*parser.Node, error) {
+	dockerfile, err := parser.Parse(strings.NewReader(onbuildCommand.Expression))
+	if err != nil {
+		return nil, fmt.Errorf("ONBUILD trigger parse | %w", err)
+	}
+
+	if len(dockerfile.AST.Children) == 0 || len(onbuildCommand.Location()) == 0 {
+		return nil, errNoTrigger
+	}
+
+	Utils.RemoveNewerFlags(dockerfile.AST)
+
+	node := dockerfile.AST.Children[0]
+	location := onbuildCommand.Location()
+	node.StartLine, node.EndLine = location[0].Start.Line, location[len(location)-1].End.Line
+
+	return node, nil
+}
@@ -0,0 +1,46 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+// ADD -> ADD instruction.
+var _ = NewRule("ADD001", "Prefer COPY over ADD for copying local files and directories.", "ADD also downloads "+
+	"remote URLs and extracts local archives, so it's less obvious what it does. Use it only for these and COPY for "+
+	"everything else.",
+	ValWarning, ValidateAdd001)
+
+var _ = RegisterFix("ADD001", "Replace ADD with COPY.", InstructionFix(FixAdd001))
+
+// FixAdd001 replaces the ADD keyword of the instruction with COPY.
+func FixAdd001(instruction string) (string, bool) {
+	keyword, _, ok := splitInstruction(instruction)
+	if !ok || !strings.EqualFold(keyword, "ADD") {
+		return "", false
+	}
+
+	keywordIndex := strings.Index(instruction, keyword)
+
+	return instruction[:keywordIndex] + "COPY" + instruction[keywordIndex+len(keyword):], true
+}
+
+func ValidateAdd001(addCommand *instructions.AddCommand) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(addCommand),
+	}
+
+	for _, src := range addCommand.SourcesAndDest.SourcePaths {
+		if isRemoteURL(src) || isGitURL(src) || isArchive(src) {
+			return result
+		}
+	}
+
+	result.SetViolated()
+	result.LocationRange.start.charNumber = 0
+	result.LocationRange.end.charNumber = len("ADD")
+
+	return result
+}
@@ -0,0 +1,76 @@
+package ruleset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateAdd001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		CommandParam string
+		IsViolation  bool
+		ExampleName  string
+		DocsContext  string
+	}{
+		{
+			CommandParam: "src/ /app/",
+			IsViolation:  true,
+			ExampleName:  "ADD of a local directory.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "go.mod go.sum /app/",
+			IsViolation:  true,
+			ExampleName:  "ADD of local files.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "rootfs.tar.gz /",
+			IsViolation:  false,
+			ExampleName:  "ADD of a local archive.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "https://example.com/app.sh /usr/local/bin/",
+			IsViolation:  false,
+			ExampleName:  "ADD of a remote URL.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "https://github.com/moby/buildkit.git#v0.10.1 /src",
+			IsViolation:  false,
+			ExampleName:  "ADD of a git repository.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ADD001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			commandArgs := strings.Fields(testCase.CommandParam)
+			command := &instructions.AddCommand{
+				SourcesAndDest: instructions.SourcesAndDest{
+					DestPath:       commandArgs[len(commandArgs)-1],
+					SourcePaths:    commandArgs[:len(commandArgs)-1],
+					SourceContents: nil,
+				},
+				Chown: "",
+				Chmod: "",
+			}
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateAdd001(command).IsViolated())
+		})
+	}
+}
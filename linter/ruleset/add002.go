@@ -0,0 +1,66 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("ADD002", "ADD of a remote URL should be verified with --checksum.", "ADD does not verify the "+
+	"content of a remote URL by default, so the image silently gets whatever the URL serves at build time. Pass "+
+	"`--checksum=sha256:<sha256>` to ADD, download it with `RUN curl -fsSLo app.tar.gz https://... && echo "+
+	"\"<sha256>  app.tar.gz\" | sha256sum -c -` instead, or COPY it from the build context.",
+	ValWarning, ValidateAdd002)
+
+func ValidateAdd002(addCommand *instructions.AddCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(addCommand),
+	}
+
+	// ADD --checksum verifies the content. The flag is removed before parsing, see Utils.RemoveNewerFlags, so it's
+	// looked up in the original instruction.
+	if hasFlag(addCommand.String(), "checksum") {
+		return result
+	}
+
+	for _, src := range addCommand.SourcesAndDest.SourcePaths {
+		if isRemoteURL(src) && !isGitURL(src) {
+			result.SetViolated()
+			result.message = "The content of " + src + " is not verified. Pass --checksum to ADD, download it with " +
+				"RUN curl and check it with sha256sum -c, or COPY it from the build context."
+			result.LocationRange = ctx.ParseLocation(src, addCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
+
+// isRemoteURL checks, whether the source of an ADD command is downloaded from a remote URL.
+func isRemoteURL(src string) bool {
+	return strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")
+}
+
+// hasFlag checks, whether the instruction, e.g. ADD --checksum=sha256:24454f83 app.tar.gz /app/, has the flag.
+func hasFlag(instruction string, name string) bool {
+	fieldList := strings.Fields(instruction)
+	if len(fieldList) == 0 {
+		return false
+	}
+
+	for _, field := range fieldList[1:] {
+		if !strings.HasPrefix(field, "--") {
+			return false
+		}
+
+		if flagName, _ := Utils.SplitKeyValue(strings.TrimPrefix(field, "--"), '='); flagName == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateAdd002(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		CommandParam string
+		IsViolation  bool
+		ExampleName  string
+		DocsContext  string
+	}{
+		{
+			CommandParam: "src/ /app/",
+			IsViolation:  false,
+			ExampleName:  "ADD of a local directory.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "https://example.com/app.sh /usr/local/bin/",
+			IsViolation:  true,
+			ExampleName:  "ADD of a remote URL.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "http://example.com/app.tar.gz /opt/",
+			IsViolation:  true,
+			ExampleName:  "ADD of a remote archive.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "--checksum=sha256:24454f830cdb571e2c4ad15481119c43b3cafd48dd869a9b2945d1036d1dc68d " +
+				"https://example.com/app.tar.gz /opt/",
+			IsViolation: false,
+			ExampleName: "ADD of a remote archive with a checksum.",
+			DocsContext: "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "--link https://example.com/app.sh /usr/local/bin/",
+			IsViolation:  true,
+			ExampleName:  "ADD --link of a remote URL.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "https://github.com/moby/buildkit.git#v0.10.1 /src",
+			IsViolation:  false,
+			ExampleName:  "ADD of a git repository.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ADD002", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, "FROM golang:1.17\nADD "+testCase.CommandParam)
+			assert.Len(t, stageList[0].Commands, 1)
+
+			command, ok := stageList[0].Commands[0].(*instructions.AddCommand)
+			assert.True(t, ok)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateAdd002(command, ctx).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("ADD003", "ADD --chmod=XXXX where XXXX should be a valid permission set value.", "",
+	ValError, ValidateAdd003)
+
+// checks ADD --chmod option format for obvious errors, as CPY002 does for COPY.
+func ValidateAdd003(addCommand *instructions.AddCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(addCommand),
+	}
+
+	result.SetViolated(!isValidChmod(addCommand.Chmod))
+
+	if result.IsViolated() {
+		result.message = "Invalid Unix permission value."
+		result.LocationRange = ctx.ParseLocation(addCommand.Chmod, addCommand.Location())
+	}
+
+	return result
+}
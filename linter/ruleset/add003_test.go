@@ -0,0 +1,49 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateAdd003(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gofmt,gofumpt,goimports
+	testCases := []struct {
+		ChmodValue  string
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{ChmodValue: "644", IsViolation: false, ExampleName: "ADD with chmod=644",
+			DocsContext: "FROM golang:1.17\nADD --chmod={{ .ChmodValue }} src.tar.gz dst/"},
+		{ChmodValue: "0755", IsViolation: false, ExampleName: "ADD with chmod=0755",
+			DocsContext: "FROM golang:1.17\nADD --chmod={{ .ChmodValue }} src.tar.gz dst/"},
+		{ChmodValue: "88", IsViolation: true, ExampleName: "ADD with chmod=88",
+			DocsContext: "FROM golang:1.17\nADD --chmod={{ .ChmodValue }} src.tar.gz dst/"},
+		{ChmodValue: "u+x", IsViolation: true, ExampleName: "ADD with chmod=u+x",
+			DocsContext: "FROM golang:1.17\nADD --chmod={{ .ChmodValue }} src.tar.gz dst/"},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ADD003", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			command := &instructions.AddCommand{
+				SourcesAndDest: instructions.SourcesAndDest{},
+				Chown:          "",
+				Chmod:          testCase.ChmodValue,
+			}
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateAdd003(command, nil).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("ADD004", "ADD chown flag should be in --chown=${USER}:${GROUP} format.", "",
+	ValError, ValidateAdd004)
+
+// checks ADD --chown option format, as CPY003 does for COPY.
+func ValidateAdd004(addCommand *instructions.AddCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(addCommand),
+	}
+
+	result.SetViolated(!isValidChown(addCommand.Chown))
+
+	if result.IsViolated() {
+		result.message = "Invalid user and group pair"
+		result.LocationRange = ctx.ParseLocation(addCommand.Chown, addCommand.Location())
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateAdd004(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gofmt,gofumpt,goimports
+	testCases := []struct {
+		ChownValue  string
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{ChownValue: "55:mygroup", IsViolation: false, ExampleName: "ADD with chown=55:mygroup",
+			DocsContext: "FROM golang:1.17\nADD --chown={{ .ChownValue }} src.tar.gz dst/"},
+		{ChownValue: "bin", IsViolation: false, ExampleName: "ADD with chown=bin",
+			DocsContext: "FROM golang:1.17\nADD --chown={{ .ChownValue }} src.tar.gz dst/"},
+		{ChownValue: "10;11", IsViolation: true, ExampleName: "ADD with chown=10;11",
+			DocsContext: "FROM golang:1.17\nADD --chown={{ .ChownValue }} src.tar.gz dst/"},
+		{ChownValue: "55:11,22", IsViolation: true, ExampleName: "ADD with chown=55:11,22",
+			DocsContext: "FROM golang:1.17\nADD --chown={{ .ChownValue }} src.tar.gz dst/"},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ADD004", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			command := &instructions.AddCommand{
+				SourcesAndDest: instructions.SourcesAndDest{},
+				Chown:          testCase.ChownValue,
+				Chmod:          "",
+			}
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateAdd004(command, nil).IsViolated())
+		})
+	}
+}
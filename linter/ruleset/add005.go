@@ -0,0 +1,51 @@
+package ruleset
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("ADD005", "ADD of a git repository should be pinned to a tag or a commit.", "Without a ref, or "+
+	"with a branch like `main`, the image gets whatever the branch points to at build time. Pin the repository in the "+
+	"URL fragment, e.g. `ADD https://github.com/moby/buildkit.git#v0.10.1 /src`.",
+	ValWarning, ValidateAdd005)
+
+func ValidateAdd005(addCommand *instructions.AddCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(addCommand),
+	}
+
+	for _, src := range addCommand.SourcesAndDest.SourcePaths {
+		if !isGitURL(src) {
+			continue
+		}
+
+		// the fragment is the ref and optionally a subdirectory, e.g. #v0.10.1:docs
+		ref := ""
+		if fragmentIndex := strings.Index(src, "#"); fragmentIndex != -1 {
+			ref = strings.SplitN(src[fragmentIndex+1:], ":", 2)[0] // nolint:gomnd
+		}
+
+		if ref == "" || Utils.EqualsEither(ref, []string{"HEAD", "main", "master"}) {
+			result.SetViolated()
+			result.message = "Repository " + src + " is not pinned."
+			result.LocationRange = ctx.ParseLocation(src, addCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
+
+// isGitURL checks, whether the source of an ADD command is a git repository, that is cloned.
+func isGitURL(src string) bool {
+	regexpGitURL := regexp.MustCompile(`^(git@|git://|ssh://|https?://[^#]+\.git(#|$))`)
+
+	return regexpGitURL.MatchString(src)
+}
@@ -0,0 +1,76 @@
+package ruleset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateAdd005(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		CommandParam string
+		IsViolation  bool
+		ExampleName  string
+		DocsContext  string
+	}{
+		{
+			CommandParam: "https://github.com/moby/buildkit.git#v0.10.1 /src",
+			IsViolation:  false,
+			ExampleName:  "Repository pinned to a tag.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "git@github.com:moby/buildkit.git#9b0bdb6:docs /docs",
+			IsViolation:  false,
+			ExampleName:  "Repository pinned to a commit.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "https://github.com/moby/buildkit.git /src",
+			IsViolation:  true,
+			ExampleName:  "Repository without a ref.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "git@github.com:moby/buildkit.git#master /src",
+			IsViolation:  true,
+			ExampleName:  "Repository pinned to a branch.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "https://example.com/app.sh /usr/local/bin/",
+			IsViolation:  false,
+			ExampleName:  "ADD of a remote URL.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ADD005", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			commandArgs := strings.Fields(testCase.CommandParam)
+			command := &instructions.AddCommand{
+				SourcesAndDest: instructions.SourcesAndDest{
+					DestPath:       commandArgs[len(commandArgs)-1],
+					SourcePaths:    commandArgs[:len(commandArgs)-1],
+					SourceContents: nil,
+				},
+				Chown: "",
+				Chmod: "",
+			}
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateAdd005(command, nil).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("ADD006", "ADD with more than one source requires the destination to end with \"/\".", "",
+	ValError, ValidateAdd006)
+
+var _ = RegisterFix("ADD006", "Append \"/\" to the destination.", InstructionFix(FixCpy004))
+
+func ValidateAdd006(addCommand *instructions.AddCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(addCommand),
+	}
+
+	if hasMultipleSources(addCommand.SourcesAndDest.SourcePaths) {
+		destination := addCommand.SourcesAndDest.DestPath
+		result.SetViolated(destination[len(destination)-1] != '/')
+		// see CPY004 for the space prefix
+		result.LocationRange = ctx.ParseLocation(" "+destination, addCommand.Location())
+		result.LocationRange.start.charNumber++
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package ruleset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateAdd006(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		CommandParam string
+		IsViolation  bool
+		ExampleName  string
+		DocsContext  string
+	}{
+		{
+			CommandParam: "app.tar.gz /app",
+			IsViolation:  false,
+			ExampleName:  "ADD with one source.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "app.tar.gz config.tar.gz /app/",
+			IsViolation:  false,
+			ExampleName:  "ADD with two sources and a directory destination.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+		{
+			CommandParam: "app.tar.gz config.tar.gz /app",
+			IsViolation:  true,
+			ExampleName:  "ADD with two sources and a file destination.",
+			DocsContext:  "FROM golang:1.17\nADD {{ .CommandParam }}",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ADD006", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			commandArgs := strings.Fields(testCase.CommandParam)
+			command := &instructions.AddCommand{
+				SourcesAndDest: instructions.SourcesAndDest{
+					DestPath:       commandArgs[len(commandArgs)-1],
+					SourcePaths:    commandArgs[:len(commandArgs)-1],
+					SourceContents: nil,
+				},
+				Chown: "",
+				Chmod: "",
+			}
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateAdd006(command, nil).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+// ARG -> ARG instruction.
+var _ = NewRule("ARG001", "ARG should not hold a secret, e.g. a password or a token.", "The values of build "+
+	"arguments are recorded in the image history, so anyone with access to the image can read them. Pass secrets "+
+	"with `RUN --mount=type=secret` instead. A secret default value is reported by SEC003.",
+	ValWarning, ValidateArg001)
+
+func ValidateArg001(argCommand *instructions.ArgCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(argCommand),
+	}
+
+	for _, arg := range argCommand.Args {
+		// a secret default value is reported by SEC003
+		if arg.Value != nil {
+			if _, ok := findAssignedSecret(arg.Key, *arg.Value); ok {
+				continue
+			}
+		}
+
+		if looksLikeSecretName(arg.Key) {
+			result.SetViolated()
+			result.message = arg.Key + " looks like a secret."
+			result.LocationRange = ctx.ParseLocation(arg.Key, argCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package ruleset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("ARG002", "ARG should be used in the stage, that declares it.", "An unused ARG is usually a "+
+	"leftover of a refactoring, or a typo in the name of the variable, that is used. As the ARGs are also "+
+	"environment variables of the RUN instructions, a script run by them may still use it, e.g. `RUN ./build.sh`.",
+	ValInfo, ValidateArg002)
+
+// proxyArgList are the predefined ARGs, that are used by the tools run in RUN instructions, without referencing them.
+var proxyArgList = []string{ // nolint:gochecknoglobals
+	"HTTP_PROXY", "HTTPS_PROXY", "FTP_PROXY", "NO_PROXY", "ALL_PROXY",
+}
+
+func ValidateArg002(stage instructions.Stage, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
+
+	for i, command := range stage.Commands {
+		argCommand, ok := command.(*instructions.ArgCommand)
+		if !ok {
+			continue
+		}
+
+		for _, arg := range argCommand.Args {
+			if Utils.EqualsEither(strings.ToUpper(arg.Key), proxyArgList) || isVariableUsed(arg.Key, stage.Commands[i:]) {
+				continue
+			}
+
+			result.SetViolated()
+			result.message = "ARG " + arg.Key + " is not used."
+			result.LocationRange = ctx.ParseLocation(arg.Key, argCommand.Location())
+
+			return result
+		}
+	}
+
+	return result
+}
+
+// isVariableUsed tells, whether any of the commands references the variable, e.g. in the default value of another
+// ARG of the declaring command.
+func isVariableUsed(name string, commandList []instructions.Command) bool {
+	for _, command := range commandList {
+		stringer, ok := command.(fmt.Stringer)
+		if !ok {
+			continue
+		}
+
+		for _, reference := range variableReferences(stringer.String()) {
+			if reference.name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
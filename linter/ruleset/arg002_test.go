@@ -0,0 +1,59 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateArg002(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "ARG used in a RUN.",
+			DocsContext: "FROM golang:1.17\nARG VERSION\nRUN echo $VERSION",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ARG not used.",
+			DocsContext: "FROM golang:1.17\nARG VERSION\nRUN make",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "ARG used in the default of another ARG.",
+			DocsContext: "FROM golang:1.17\nARG VERSION=1.0 TAG=v${VERSION}\nLABEL tag=$TAG",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Predefined proxy ARG.",
+			DocsContext: "FROM golang:1.17\nARG HTTP_PROXY\nRUN make",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ARG used only in another stage.",
+			DocsContext: "FROM golang:1.17\nARG VERSION\nFROM alpine\nARG VERSION\nRUN echo $VERSION",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ARG002", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateArg002(stageList[0], ctx).IsViolated())
+		})
+	}
+}
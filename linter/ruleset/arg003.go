@@ -0,0 +1,58 @@
+package ruleset
+
+import (
+	"fmt"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("ARG003", "Variable should be declared with ARG or ENV before it's used.", "An undeclared "+
+	"variable is substituted with an empty string, e.g. because of a typo in its name, or because it's declared "+
+	"only in another stage. References with a default value, like ${VERSION:-1.0}, and the variables of RUN, CMD "+
+	"and ENTRYPOINT, that are left to the shell, are not checked. Variables set by the base image, e.g. PATH, are "+
+	"not known, so they may need a suppression.",
+	ValInfo, ValidateArg003)
+
+// wellKnownVariableList are the environment variables, that are set in most base images.
+var wellKnownVariableList = []string{"HOME", "HOSTNAME", "PATH"} // nolint:gochecknoglobals
+
+func ValidateArg003(stage instructions.Stage, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
+
+	declaredList := append([]string{}, wellKnownVariableList...)
+	// meta ARGs, that are not re-declared, are reported by ARG004
+	for _, metaArg := range ctx.MetaArgs() {
+		declaredList = append(declaredList, declaredVariables(&metaArg)...) // nolint:gosec
+	}
+
+	for _, command := range stage.Commands {
+		stringer, ok := command.(fmt.Stringer)
+		if !ok || !isVariableSubstituted(command) {
+			declaredList = append(declaredList, declaredVariables(command)...)
+
+			continue
+		}
+
+		// references to the variables of the same ENV are reported by ENV002
+		commandDeclaredList := declaredVariables(command)
+
+		for _, reference := range variableReferences(stringer.String()) {
+			if reference.hasModifier || Utils.EqualsEither(reference.name, declaredList) ||
+				Utils.EqualsEither(reference.name, commandDeclaredList) {
+				continue
+			}
+
+			result.SetViolated()
+			result.message = reference.name + " is not declared."
+			result.LocationRange = ctx.ParseLocation(reference.text, command.Location())
+
+			return result
+		}
+
+		declaredList = append(declaredList, commandDeclaredList...)
+	}
+
+	return result
+}
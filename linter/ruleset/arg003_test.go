@@ -0,0 +1,64 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateArg003(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "Declared ARG.",
+			DocsContext: "FROM golang:1.17\nARG VERSION\nLABEL version=$VERSION",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Undeclared variable.",
+			DocsContext: "FROM golang:1.17\nLABEL version=$VERSION",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Variable declared later.",
+			DocsContext: "FROM golang:1.17\nLABEL version=$VERSION\nARG VERSION",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Variable with a default value.",
+			DocsContext: "FROM golang:1.17\nLABEL version=${VERSION:-dev}",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Shell variable in RUN.",
+			DocsContext: "FROM golang:1.17\nRUN for f in *; do echo $f; done",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Variable of the base image.",
+			DocsContext: "FROM golang:1.17\nENV PATH=/app/bin:$PATH",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ARG003", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateArg003(stageList[0], ctx).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package ruleset
+
+import (
+	"fmt"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("ARG004", "Meta ARG should be re-declared in the stage, that uses it.", "An ARG before the first "+
+	"FROM can only be used in the FROM instructions. To use it inside a stage, it has to be re-declared there "+
+	"without a value, e.g. `ARG VERSION`, otherwise it's empty.",
+	ValWarning, ValidateArg004)
+
+func ValidateArg004(stage instructions.Stage, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
+
+	metaArgNameList := make([]string, 0)
+	for _, metaArg := range ctx.MetaArgs() {
+		metaArgNameList = append(metaArgNameList, declaredVariables(&metaArg)...) // nolint:gosec
+	}
+
+	declaredList := make([]string, 0)
+
+	for _, command := range stage.Commands {
+		stringer, ok := command.(fmt.Stringer)
+		if !ok {
+			continue
+		}
+
+		for _, reference := range variableReferences(stringer.String()) {
+			if !Utils.EqualsEither(reference.name, metaArgNameList) || Utils.EqualsEither(reference.name, declaredList) {
+				continue
+			}
+
+			result.SetViolated()
+			result.message = "Meta ARG " + reference.name + " is not re-declared in the stage."
+			result.LocationRange = ctx.ParseLocation(reference.text, command.Location())
+
+			return result
+		}
+
+		declaredList = append(declaredList, declaredVariables(command)...)
+	}
+
+	return result
+}
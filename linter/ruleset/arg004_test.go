@@ -0,0 +1,49 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateArg004(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "Meta ARG used in FROM.",
+			DocsContext: "ARG VERSION=1.17\nFROM golang:${VERSION}\nRUN make",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Meta ARG used in the stage.",
+			DocsContext: "ARG VERSION=1.17\nFROM golang:${VERSION}\nRUN echo $VERSION",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Meta ARG re-declared in the stage.",
+			DocsContext: "ARG VERSION=1.17\nFROM golang:${VERSION}\nARG VERSION\nRUN echo $VERSION",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ARG004", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateArg004(stageList[0], ctx).IsViolated())
+		})
+	}
+}
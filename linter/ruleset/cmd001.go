@@ -9,7 +9,9 @@ import (
 var _ = NewRule("CMD001", "Prefer JSON notation array format for CMD and ENTRYPOINT", "", ValWarning,
 	ValidateCmd001)
 
-func ValidateCmd001(cmdCommand *instructions.CmdCommand) RuleValidationResult {
+var _ = RegisterFix("CMD001", "Convert to JSON array form.", InstructionFix(FixEnt001))
+
+func ValidateCmd001(cmdCommand *instructions.CmdCommand, ctx *Context) RuleValidationResult {
 	argStr := cmdCommand.String()[len(cmdCommand.Name()):]
 	argStr = strings.TrimSpace(argStr)
 	lineNum := cmdCommand.Location()[0].Start.Line
@@ -23,5 +25,5 @@ func ValidateCmd001(cmdCommand *instructions.CmdCommand) RuleValidationResult {
 		}
 	}
 
-	return ValidateEnt001(entrypointCommand)
+	return ValidateEnt001(entrypointCommand, ctx)
 }
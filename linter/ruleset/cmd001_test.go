@@ -67,7 +67,7 @@ func TestValidateCmd001(t *testing.T) {
 			cmdCommand, err := RuleSet.NewCmdCommand(testCase.CmdStr, 2)
 			assert.Nil(t, err)
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCmd001(cmdCommand).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCmd001(cmdCommand, nil).IsViolated())
 		})
 	}
 }
@@ -0,0 +1,127 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+// Context is the context of a single linting run, that the rules validate the Dockerfile AST nodes in: the raw
+// Dockerfile source, its file name, its meta ARGs and the options of the rule from the config. As each run has its own
+// context, rules can validate many Dockerfiles concurrently.
+//
+// A nil Context has no source, so the locations fall back to the line ranges of the instructions.
+type Context struct {
+	fileName    string
+	rawParser   Parser.RawDockerfileParser
+	metaArgList []instructions.ArgCommand
+	options     RuleOptions
+}
+
+// NewContext returns the context of linting the Dockerfile source of fileName. The file name may be empty, e.g. for
+// unsaved editor buffers.
+func NewContext(fileName string, source string) *Context {
+	return &Context{
+		fileName:    fileName,
+		rawParser:   Parser.NewRawDockerfileParser(source),
+		metaArgList: nil,
+		options:     nil,
+	}
+}
+
+// WithMetaArgs returns a copy of the context with the meta ARGs of the Dockerfile, i.e. the ARGs before the first FROM.
+func (ctx *Context) WithMetaArgs(metaArgList []instructions.ArgCommand) *Context {
+	result := ctx.withOptions(ctx.Options())
+	result.metaArgList = metaArgList
+
+	return result
+}
+
+// FileName returns the file name of the Dockerfile, if any.
+func (ctx *Context) FileName() string {
+	if ctx == nil {
+		return ""
+	}
+
+	return ctx.fileName
+}
+
+// Source returns the raw Dockerfile source.
+func (ctx *Context) Source() string {
+	if ctx == nil {
+		return ""
+	}
+
+	return ctx.rawParser.RawStr()
+}
+
+// HasSource tells whether the raw Dockerfile source is available to locate strings in.
+func (ctx *Context) HasSource() bool {
+	return ctx != nil && ctx.rawParser.IsInitialized()
+}
+
+// MetaArgs returns the meta ARGs of the Dockerfile, i.e. the ARGs before the first FROM.
+func (ctx *Context) MetaArgs() []instructions.ArgCommand {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.metaArgList
+}
+
+// Options returns the options of the rule, that validates in the context.
+func (ctx *Context) Options() RuleOptions {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.options
+}
+
+// withOptions returns a copy of the context with the options of a rule.
+func (ctx *Context) withOptions(options RuleOptions) *Context {
+	result := Context{fileName: "", rawParser: Parser.RawDockerfileParser{}, metaArgList: nil, options: options}
+	if ctx != nil {
+		result.fileName = ctx.fileName
+		result.rawParser = ctx.rawParser
+		result.metaArgList = ctx.metaArgList
+	}
+
+	return &result
+}
+
+// ParseLocation locates str in the raw source within window, e.g. the location of an instruction. It falls back to the
+// window, if there is no source or str is not found.
+func (ctx *Context) ParseLocation(str string, window []parser.Range) LocationRange {
+	if !ctx.HasSource() {
+		return BKRangeSliceToLocationRange(window)
+	}
+
+	location := NewLocationFrom4Int(
+		ctx.rawParser.StringLocation(str, window),
+	)
+
+	if location.Start().LineNumber() == -1 {
+		return BKRangeSliceToLocationRange(window)
+	}
+
+	return location
+}
+
+// ParseLocationSlice locates each string of strSlice in the raw source within window, see ParseLocation.
+func (ctx *Context) ParseLocationSlice(strSlice []string, window []parser.Range) []LocationRange {
+	if !ctx.HasSource() {
+		return []LocationRange{BKRangeSliceToLocationRange(window)}
+	}
+
+	location := NewLocationFrom4IntSlice(
+		ctx.rawParser.StringSliceLocation(strSlice, window),
+	)
+
+	if location[0].Start().LineNumber() == -1 {
+		return []LocationRange{BKRangeSliceToLocationRange(window)}
+	}
+
+	return location
+}
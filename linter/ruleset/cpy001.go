@@ -14,9 +14,18 @@ var _ = NewRule(
 - `+"`chown`"+` should be in `+"`user:group`"+` format.`,
 	ValError, ValidateCpy001)
 
+var _ = RegisterFix("CPY001", "Prefix flags with two dashes.", InstructionFix(FixCpy001))
+
+var regexpCpy001FlagDashes = regexp.MustCompile(`(\s)(-|-{3,})?(chmod|chown|from) ?=`) // nolint:gochecknoglobals
+
+// FixCpy001 prefixes the COPY flags with exactly two dashes, e.g. "-chmod=" -> "--chmod=".
+func FixCpy001(instruction string) (string, bool) {
+	return regexpCpy001FlagDashes.ReplaceAllString(instruction, "${1}--${3}="), true
+}
+
 // checks COPY options format for obvious errors
 // --[option]=...
-func ValidateCpy001(copyCommand *instructions.CopyCommand) RuleValidationResult {
+func ValidateCpy001(copyCommand *instructions.CopyCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(copyCommand),
@@ -29,7 +38,7 @@ func ValidateCpy001(copyCommand *instructions.CopyCommand) RuleValidationResult
 		result.message = "Flags must be prefixed with exactly two dashes."
 
 		wrongFlagStr := regexpWrongNumberOfDashViolation.FindString(copyCommand.SourcesAndDest.SourcePaths[0])
-		result.LocationRange = ParseLocationFromRawParser(wrongFlagStr, copyCommand.Location())
+		result.LocationRange = ctx.ParseLocation(wrongFlagStr, copyCommand.Location())
 	}
 
 	// TODO: support invalid flag. Note: it might need contribution to buildkit.
@@ -131,7 +131,7 @@ func TestValidateCpy001(t *testing.T) {
 				t.Error("cannot type assert instruction to *instructions.CopyCommand")
 			}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy001(command).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy001(command, nil).IsViolated())
 		})
 	}
 }
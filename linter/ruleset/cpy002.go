@@ -11,7 +11,7 @@ var _ = NewRule("CPY002", "COPY --chmod=XXXX where XXXX should be a valid permis
 
 // checks COPY --chmod option format for obvious errors
 // --chmod=XXXX, where XXXX is a valid permission set value.
-func ValidateCpy002(copyCommand *instructions.CopyCommand) RuleValidationResult {
+func ValidateCpy002(copyCommand *instructions.CopyCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(copyCommand),
@@ -19,14 +19,20 @@ func ValidateCpy002(copyCommand *instructions.CopyCommand) RuleValidationResult
 
 	locationOffset := 5
 
-	regexpUnixPermission := regexp.MustCompile("^[0-7]{1,4}$")
-	result.SetViolated(!regexpUnixPermission.MatchString(copyCommand.Chmod) && len(copyCommand.Chmod) != 0)
+	result.SetViolated(!isValidChmod(copyCommand.Chmod))
 	result.LocationRange.start.charNumber = locationOffset
 
 	if result.IsViolated() {
 		result.message = "Invalid Unix permission value."
-		result.LocationRange = ParseLocationFromRawParser(copyCommand.Chmod, copyCommand.Location())
+		result.LocationRange = ctx.ParseLocation(copyCommand.Chmod, copyCommand.Location())
 	}
 
 	return result
 }
+
+// isValidChmod checks the value of a --chmod flag. An empty value means the flag is not set.
+func isValidChmod(chmod string) bool {
+	regexpUnixPermission := regexp.MustCompile("^[0-7]{1,4}$")
+
+	return regexpUnixPermission.MatchString(chmod) || len(chmod) == 0
+}
@@ -44,7 +44,7 @@ func TestValidateCpy002(t *testing.T) {
 				Chmod:          testCase.ChmodValue,
 			}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy002(command).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy002(command, nil).IsViolated())
 		})
 	}
 }
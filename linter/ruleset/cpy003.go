@@ -9,19 +9,25 @@ import (
 var _ = NewRule("CPY003", "COPY chown flag should be in --chown=${USER}:${GROUP} format.", "",
 	ValError, ValidateCpy003)
 
-func ValidateCpy003(copyCommand *instructions.CopyCommand) RuleValidationResult {
+func ValidateCpy003(copyCommand *instructions.CopyCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(copyCommand),
 	}
 
-	regexpUserGroupPair := regexp.MustCompile(`^([a-zA-Z0-9]|[a-zA-Z0-9]:[a-zA-Z0-9]){1,}$`)
-	result.SetViolated(!regexpUserGroupPair.MatchString(copyCommand.Chown) && len(copyCommand.Chown) != 0)
+	result.SetViolated(!isValidChown(copyCommand.Chown))
 
 	if result.IsViolated() {
 		result.message = "Invalid user and group pair"
-		result.LocationRange = ParseLocationFromRawParser(copyCommand.Chown, copyCommand.Location())
+		result.LocationRange = ctx.ParseLocation(copyCommand.Chown, copyCommand.Location())
 	}
 
 	return result
 }
+
+// isValidChown checks the value of a --chown flag. An empty value means the flag is not set.
+func isValidChown(chown string) bool {
+	regexpUserGroupPair := regexp.MustCompile(`^([a-zA-Z0-9]|[a-zA-Z0-9]:[a-zA-Z0-9]){1,}$`)
+
+	return regexpUserGroupPair.MatchString(chown) || len(chown) == 0
+}
@@ -54,7 +54,7 @@ func TestValidateCpy003(t *testing.T) {
 				Chmod:          "",
 			}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy003(command).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy003(command, nil).IsViolated())
 		})
 	}
 }
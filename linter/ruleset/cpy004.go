@@ -9,21 +9,27 @@ import (
 var _ = NewRule("CPY004", "COPY with more than one source requires the destination to end with \"/\".", "",
 	ValError, ValidateCpy004)
 
-func ValidateCpy004(copyCommand *instructions.CopyCommand) RuleValidationResult {
+var _ = RegisterFix("CPY004", "Append \"/\" to the destination.", InstructionFix(FixCpy004))
+
+// FixCpy004 appends "/" to the destination, i.e. the last argument of the COPY instruction.
+func FixCpy004(instruction string) (string, bool) {
+	instruction = strings.TrimRight(instruction, " \t\r")
+
+	// JSON form or quoted destination
+	if strings.HasSuffix(instruction, "]") || strings.HasSuffix(instruction, "\"") {
+		return "", false
+	}
+
+	return instruction + "/", true
+}
+
+func ValidateCpy004(copyCommand *instructions.CopyCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(copyCommand),
 	}
 
-	sourceCount := len(copyCommand.SourcesAndDest.SourcePaths)
-	// in case of CPY002 violation, the flag can end up in the sources list
-	for _, src := range copyCommand.SourcesAndDest.SourcePaths {
-		if strings.HasPrefix(src, "-") {
-			sourceCount--
-		}
-	}
-
-	if sourceCount > 1 {
+	if hasMultipleSources(copyCommand.SourcesAndDest.SourcePaths) {
 		// is valid
 		destination := copyCommand.SourcesAndDest.DestPath
 		destinationLastChar := destination[len(destination)-1]
@@ -32,9 +38,22 @@ func ValidateCpy004(copyCommand *instructions.CopyCommand) RuleValidationResult
 		// note: prefixing the destination with a space in order to avoid the edge case, where the destination can be
 		//       found in the source as well as a substring. This prefix need to be cut off, that's why the increment at
 		//       the end.
-		result.LocationRange = ParseLocationFromRawParser(" "+destination, copyCommand.Location())
+		result.LocationRange = ctx.ParseLocation(" "+destination, copyCommand.Location())
 		result.LocationRange.start.charNumber++
 	}
 
 	return result
 }
+
+// hasMultipleSources checks, whether a COPY or ADD command has more than one source.
+func hasMultipleSources(sourcePaths []string) bool {
+	sourceCount := len(sourcePaths)
+	// in case of CPY002 violation, the flag can end up in the sources list
+	for _, src := range sourcePaths {
+		if strings.HasPrefix(src, "-") {
+			sourceCount--
+		}
+	}
+
+	return sourceCount > 1
+}
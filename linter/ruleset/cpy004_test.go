@@ -57,7 +57,7 @@ func TestValidateCpy004(t *testing.T) {
 				Chmod: "",
 			}
 
-			result := !RuleSet.ValidateCpy004(command).IsViolated()
+			result := !RuleSet.ValidateCpy004(command, nil).IsViolated()
 
 			assert.Equal(t, testCase.IsViolation, result)
 		})
@@ -10,24 +10,33 @@ var _ = NewRule("CPY005", "Prefer ADD over COPY for extracting local archives in
 	ValWarning, ValidateCpy005)
 
 func ValidateCpy005(copyCommand *instructions.CopyCommand) RuleValidationResult {
-	archiveExtensionList := []string{
-		".7z", ".gz", ".lz", "lzo", "lzma", ".tar", ".tb2", ".tbz", ".tbz2", ".tgz",
-		".tlz", ".tpz", ".txz", ".tZ", "zx", ".Z", ".zip",
-	}
-
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(copyCommand),
 	}
 
-	fileExt := path.Ext(copyCommand.SourcesAndDest.SourcePaths[0])
+	if isArchive(copyCommand.SourcesAndDest.SourcePaths[0]) {
+		result.SetViolated()
+		result.LocationRange.start.charNumber = 0
+		result.LocationRange.end.charNumber = len("COPY")
+	}
+
+	return result
+}
+
+// isArchive checks, whether the file is an archive, that ADD extracts, based on its extension.
+func isArchive(filePath string) bool {
+	archiveExtensionList := []string{
+		".7z", ".gz", ".lz", "lzo", "lzma", ".tar", ".tb2", ".tbz", ".tbz2", ".tgz",
+		".tlz", ".tpz", ".txz", ".tZ", "zx", ".Z", ".zip",
+	}
+
+	fileExt := path.Ext(filePath)
 	for _, archiveExt := range archiveExtensionList {
 		if fileExt == archiveExt {
-			result.SetViolated()
-			result.LocationRange.start.charNumber = 0
-			result.LocationRange.end.charNumber = len("COPY")
+			return true
 		}
 	}
 
-	return result
+	return false
 }
@@ -9,7 +9,7 @@ import (
 var _ = NewRule("CPY006", "COPY --from value should not be the same as the stage.", "", ValError,
 	ValidateCpy006)
 
-func ValidateCpy006(stage instructions.Stage) RuleValidationResult {
+func ValidateCpy006(stage instructions.Stage, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{isViolated: false, LocationRange: LocationRange{}}
 
 	for _, command := range stage.Commands {
@@ -17,7 +17,7 @@ func ValidateCpy006(stage instructions.Stage) RuleValidationResult {
 			if len(copyCommand.From) > 0 && (copyCommand.From == stage.Name || copyCommand.From == stage.BaseName ||
 				Utils.MatchDockerImageNames(copyCommand.From, stage.BaseName)) {
 				result.SetViolated()
-				result.LocationRange = ParseLocationFromRawParser(copyCommand.From, copyCommand.Location())
+				result.LocationRange = ctx.ParseLocation(copyCommand.From, copyCommand.Location())
 			}
 		}
 	}
@@ -120,7 +120,7 @@ func TestValidateCpy006(t *testing.T) {
 				},
 			}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy006(stage).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateCpy006(stage, nil).IsViolated())
 		})
 	}
 }
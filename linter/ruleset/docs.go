@@ -4,13 +4,16 @@ package ruleset
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"html"
 	"html/template"
 	"os"
 	"path"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/Masterminds/sprig"
 	Log "github.com/sirupsen/logrus"
@@ -28,15 +31,11 @@ type RuleDoc struct {
 	TestDocs []TestCaseDocs
 }
 
-// TestCaseDocs holds common test case parts for lint rules, mainly used in RuleDoc.
-type TestCaseDocs struct {
-	ExampleName string
-	DocsContext string
-	IsViolation bool
-}
-
 var ruleDocMap = RuleDocMap{} // nolint:gochecknoglobals
 
+// Test cases are running in parallel, while each of them regenerates the docs.
+var ruleDocMapLock = sync.Mutex{} // nolint:gochecknoglobals
+
 // ExtractDocFieldsFromTestCase parses the common fields of an arbitrary test case struct into a TestCaseDocs.
 // It return an error is any of the fields of a TestCaseDocs is missing from a lint Rule test case.
 func ExtractDocFieldsFromTestCase(testDocsReflect reflect.Value, parent reflect.Value, i int) (TestCaseDocs, error) {
@@ -118,6 +117,9 @@ func RegisterTestCaseDocs(ruleID string, testDocsIntrfc interface{}) {
 		return
 	}
 
+	ruleDocMapLock.Lock()
+	defer ruleDocMapLock.Unlock()
+
 	ruleDocMap[ruleID] = RuleDoc{
 		Rule:     &rule,
 		TestDocs: testDocsSlice,
@@ -125,6 +127,7 @@ func RegisterTestCaseDocs(ruleID string, testDocsIntrfc interface{}) {
 	}
 
 	GenerateRuleDocs()
+	GenerateRuleExamples()
 }
 
 func GenerateRuleDocs() {
@@ -159,3 +162,44 @@ func GenerateRuleDocs() {
 		}
 	}
 }
+
+// GenerateRuleExamples merges the registered test case docs into the examples file, which is embedded into the binary.
+// See GetExamples.
+func GenerateRuleExamples() {
+	exampleMap := map[string][]TestCaseDocs{}
+
+	// keep the examples of the rules, that are not registered in this run, e.g. when only a subset of tests is run
+	if fileContent, err := os.ReadFile(examplesFileName); err == nil {
+		if err := json.Unmarshal(fileContent, &exampleMap); err != nil {
+			Log.Error(err)
+		}
+	}
+
+	for ruleID, ruleDoc := range ruleDocMap {
+		testDocsSlice := make([]TestCaseDocs, 0, len(ruleDoc.TestDocs))
+
+		// the examples are printed as plain text, so html/template escaping is reverted, e.g. "&amp;&amp;" -> "&&"
+		for _, testCaseDocs := range ruleDoc.TestDocs {
+			testCaseDocs.DocsContext = html.UnescapeString(testCaseDocs.DocsContext)
+			testDocsSlice = append(testDocsSlice, testCaseDocs)
+		}
+
+		exampleMap[ruleID] = testDocsSlice
+	}
+
+	// keep the examples readable, e.g. "&&"
+	strBuilder := &strings.Builder{}
+	encoder := json.NewEncoder(strBuilder)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(exampleMap); err != nil {
+		Log.Error(err)
+
+		return
+	}
+
+	if err := os.WriteFile(examplesFileName, []byte(strBuilder.String()), 0o600); err != nil { // nolint:gomnd
+		Log.Error(err)
+	}
+}
@@ -7,13 +7,21 @@ type DocsReference string
 const ToDoReference = DocsReference("TODO")
 
 var DocsReferenceMap = map[string]DocsReference{ // nolint:gochecknoglobals
+	"ADD": DocsReference("https://docs.docker.com/engine/reference/builder/#add"),
+	"ARG": DocsReference("https://docs.docker.com/engine/reference/builder/#arg"),
 	"CPY": DocsReference("https://docs.docker.com/engine/reference/builder/#copy"),
 	"ENV": DocsReference("https://docs.docker.com/engine/reference/builder/#env"),
 	"EXP": DocsReference("https://docs.docker.com/engine/reference/builder/#expose"),
 	"FRM": DocsReference("https://docs.docker.com/engine/reference/builder/#from"),
+	"HLT": DocsReference("https://docs.docker.com/engine/reference/builder/#healthcheck"),
+	"IGN": DocsReference("https://github.com/CreMindES/whalelint#inline-suppression"),
+	"ONB": DocsReference("https://docs.docker.com/engine/reference/builder/#onbuild"),
 	"RUN": DocsReference("https://docs.docker.com/engine/reference/builder/#run"),
+	"SEC": DocsReference("https://docs.docker.com/engine/reference/builder/#run---mounttypesecret"),
+	"SIG": DocsReference("https://docs.docker.com/engine/reference/builder/#stopsignal"),
 	"STL": DocsReference("https://docs.docker.com/engine/reference/builder/#from"),
 	"STS": DocsReference("https://docs.docker.com/engine/reference/builder/#from"),
 	"USR": DocsReference("https://docs.docker.com/engine/reference/builder/#user"),
+	"VOL": DocsReference("https://docs.docker.com/engine/reference/builder/#volume"),
 	"WKD": DocsReference("https://docs.docker.com/engine/reference/builder/#workdir"),
 }
@@ -9,10 +9,12 @@ import (
 var _ = NewRule("ENT001", "Prefer JSON notation array format for CMD and ENTRYPOINT", "", ValWarning,
 	ValidateEnt001)
 
-func ValidateEnt001(entrypointCommand *instructions.EntrypointCommand) RuleValidationResult {
+var _ = RegisterFix("ENT001", "Convert to JSON array form.", InstructionFix(FixEnt001))
+
+func ValidateEnt001(entrypointCommand *instructions.EntrypointCommand, ctx *Context) RuleValidationResult {
 	// Get location, which also covers the case of multi line string
 	locationRange := UnionOfLocationRanges(
-		ParseLocationSliceFromRawParser(entrypointCommand.ShellDependantCmdLine.CmdLine, entrypointCommand.Location()),
+		ctx.ParseLocationSlice(entrypointCommand.ShellDependantCmdLine.CmdLine, entrypointCommand.Location()),
 	)
 
 	// buildkit's instructions package handleJSONArgs parses CMD, ENTRYPOINT, SHELL and RUN commands.
@@ -48,3 +50,32 @@ func ValidateEnt001(entrypointCommand *instructions.EntrypointCommand) RuleValid
 		LocationRange: locationRange,
 	}
 }
+
+// FixEnt001 rewrites the shell form of CMD and ENTRYPOINT into the JSON array form. Commands relying on the shell,
+// e.g. variables or operators, are wrapped into ["/bin/sh", "-c", "..."] to keep their behavior.
+func FixEnt001(instruction string) (string, bool) {
+	keyword, argStr, ok := splitInstruction(instruction)
+
+	// invalid JSON, it's not clear what was meant
+	if !ok || strings.HasPrefix(argStr, "[") {
+		return "", false
+	}
+
+	argList := strings.Fields(argStr)
+	if strings.ContainsAny(argStr, "$&|;<>()`*?~\\\"'") {
+		argList = []string{"/bin/sh", "-c", argStr}
+	}
+
+	quotedArgList := make([]string, len(argList))
+
+	for i, arg := range argList {
+		quotedArg, err := marshalJSONString(arg)
+		if err != nil {
+			return "", false
+		}
+
+		quotedArgList[i] = quotedArg
+	}
+
+	return keyword + " [" + strings.Join(quotedArgList, ", ") + "]", true
+}
@@ -67,7 +67,7 @@ func TestValidateEnt001(t *testing.T) {
 			entrypointCommand, err := RuleSet.NewEntrypointCommand(testCase.EntrypointStr, 2)
 			assert.Nil(t, err)
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateEnt001(entrypointCommand).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateEnt001(entrypointCommand, nil).IsViolated())
 		})
 	}
 }
@@ -0,0 +1,50 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+// ENV -> ENV instruction.
+var _ = NewRule("ENV001", "Use the ENV key=value format instead of the legacy ENV key value format.", "The legacy "+
+	"format sets a single variable to the rest of the line, including the spaces, which is easy to misread, e.g. "+
+	"`ENV PORT 8080 DEBUG 1`. The key=value format is also the one, that can set multiple variables at once.",
+	ValWarning, ValidateEnv001)
+
+var _ = RegisterFix("ENV001", "Rewrite to ENV key=\"value\".", InstructionFix(FixEnv001))
+
+// FixEnv001 rewrites "ENV key value" to "ENV key="value"". Values with quotes or backslashes are left to the user.
+func FixEnv001(instruction string) (string, bool) {
+	keyword, argStr, ok := splitInstruction(instruction)
+	if !ok || strings.ContainsAny(argStr, "\"'\\") {
+		return "", false
+	}
+
+	argList := strings.SplitN(argStr, " ", 2) // nolint:gomnd
+	if len(argList) != 2 || strings.Contains(argList[0], "=") {
+		return "", false
+	}
+
+	return keyword + " " + argList[0] + "=\"" + strings.TrimSpace(argList[1]) + "\"", true
+}
+
+func ValidateEnv001(envCommand *instructions.EnvCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(envCommand),
+	}
+
+	// the parsed key value pairs are the same for both formats, so it's told from the source code
+	_, argStr, ok := splitInstruction(envCommand.String())
+	if !ok || len(envCommand.Env) != 1 {
+		return result
+	}
+
+	if key := strings.Fields(argStr)[0]; !strings.Contains(key, "=") {
+		result.SetViolated()
+		result.LocationRange = ctx.ParseLocation(key, envCommand.Location())
+	}
+
+	return result
+}
@@ -0,0 +1,57 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateEnv001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "ENV in key=value format.",
+			DocsContext: "FROM golang:1.17\nENV PORT=8080",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "ENV of multiple variables.",
+			DocsContext: "FROM golang:1.17\nENV PORT=8080 DEBUG=1",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ENV in legacy format.",
+			DocsContext: "FROM golang:1.17\nENV PORT 8080",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ENV in legacy format with spaces.",
+			DocsContext: "FROM golang:1.17\nENV GREETING hello world",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ENV001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+			command, ok := stageList[0].Commands[0].(*instructions.EnvCommand)
+			assert.True(t, ok)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateEnv001(command, ctx).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("ENV002", "ENV should not reference a variable, that is set in the same instruction.", "The "+
+	"variables of an ENV instruction are substituted before any of them is set, so in `ENV A=1 B=$A` B gets the "+
+	"previous value of A, not 1. Set them in separate ENV instructions instead.",
+	ValWarning, ValidateEnv002)
+
+func ValidateEnv002(envCommand *instructions.EnvCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(envCommand),
+	}
+
+	keyList := make([]string, 0, len(envCommand.Env))
+
+	for _, env := range envCommand.Env {
+		for _, reference := range variableReferences(env.Value) {
+			if Utils.EqualsEither(reference.name, keyList) {
+				result.SetViolated()
+				result.message = reference.name + " is set in the same instruction."
+				result.LocationRange = ctx.ParseLocation(reference.text, envCommand.Location())
+
+				return result
+			}
+		}
+
+		keyList = append(keyList, env.Key)
+	}
+
+	return result
+}
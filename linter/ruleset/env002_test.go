@@ -0,0 +1,57 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateEnv002(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "ENV referencing a previous variable.",
+			DocsContext: "FROM golang:1.17\nENV PATH=/app/bin:$PATH",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ENV referencing a variable of the same instruction.",
+			DocsContext: "FROM golang:1.17\nENV APP_HOME=/app APP_BIN=${APP_HOME}/bin",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "ENV referencing a variable set later in the same instruction.",
+			DocsContext: "FROM golang:1.17\nENV APP_BIN=$APP_HOME/bin APP_HOME=/app",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "ENV with an escaped reference.",
+			DocsContext: "FROM golang:1.17\nENV APP_HOME=/app GREETING=\\$APP_HOME",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ENV002", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+			command, ok := stageList[0].Commands[0].(*instructions.EnvCommand)
+			assert.True(t, ok)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateEnv002(command, ctx).IsViolated())
+		})
+	}
+}
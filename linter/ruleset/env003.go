@@ -0,0 +1,34 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("ENV003", "ENV should not hold a secret, e.g. a password or a token.", "ENV variables are "+
+	"persisted in the image and its history, so anyone with access to the image can read them. Pass secrets with "+
+	"`RUN --mount=type=secret` instead. A secret value is reported by SEC002.",
+	ValWarning, ValidateEnv003)
+
+func ValidateEnv003(envCommand *instructions.EnvCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(envCommand),
+	}
+
+	for _, env := range envCommand.Env {
+		// a secret value is reported by SEC002
+		if _, ok := findAssignedSecret(env.Key, env.Value); ok {
+			continue
+		}
+
+		if looksLikeSecretName(env.Key) {
+			result.SetViolated()
+			result.message = env.Key + " looks like a secret."
+			result.LocationRange = ctx.ParseLocation(env.Key, envCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,40 @@
+package ruleset
+
+import (
+	_ "embed" // for the rule examples file
+	"encoding/json"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TestCaseDocs holds common test case parts for lint rules, mainly used in RuleDoc and as rule examples.
+type TestCaseDocs struct {
+	ExampleName string
+	DocsContext string
+	IsViolation bool
+}
+
+// examplesFileName is the file that the examples are harvested into from the test cases with the ruledocs build tag.
+const examplesFileName = "examples.json"
+
+//go:embed examples.json
+var examplesFileContent []byte // nolint:gochecknoglobals
+
+var (
+	exampleMap     = map[string][]TestCaseDocs{} // nolint:gochecknoglobals
+	exampleMapOnce = sync.Once{}                 // nolint:gochecknoglobals
+)
+
+// GetExamples returns the good and bad examples of a rule, harvested from its test cases. The ID is case-insensitive.
+// Rules without registered test case docs have no examples.
+func GetExamples(ruleID string) []TestCaseDocs {
+	exampleMapOnce.Do(func() {
+		if err := json.Unmarshal(examplesFileContent, &exampleMap); err != nil {
+			log.Error("RuleSet | failed to parse the embedded rule examples | ", err)
+		}
+	})
+
+	return exampleMap[strings.ToUpper(ruleID)]
+}
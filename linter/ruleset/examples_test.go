@@ -0,0 +1,29 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestGetExamples(t *testing.T) {
+	t.Parallel()
+
+	exampleList := RuleSet.GetExamples("run006")
+
+	assert.NotEmpty(t, exampleList)
+	assert.Equal(t, exampleList, RuleSet.GetExamples("RUN006"))
+	assert.Contains(t, exampleList[0].DocsContext, "&&")
+
+	// both good and bad examples
+	hasViolation := false
+	for _, example := range exampleList {
+		hasViolation = hasViolation || example.IsViolation
+	}
+
+	assert.True(t, hasViolation)
+
+	assert.Empty(t, RuleSet.GetExamples("XXX000"))
+}
@@ -10,7 +10,7 @@ import (
 
 var _ = NewRule("EXP001", "Expose a valid UNIX port.", "", ValError, ValidateExp001)
 
-func ValidateExp001(exposeCommand *instructions.ExposeCommand) RuleValidationResult {
+func ValidateExp001(exposeCommand *instructions.ExposeCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(exposeCommand),
@@ -29,12 +29,12 @@ func ValidateExp001(exposeCommand *instructions.ExposeCommand) RuleValidationRes
 			isProtocolValid := checkProtocolValue(protocol)
 
 			result.SetViolated(!isPortValid || !isProtocolValid)
-			result.LocationRange = ParseLocationFromRawParser(portStr, exposeCommand.Location())
+			result.LocationRange = ctx.ParseLocation(portStr, exposeCommand.Location())
 		} else {
 			// port only format
 			isPortValid := Utils.IsUnixPortValid(portStr)
 			result.SetViolated(!isPortValid)
-			result.LocationRange = ParseLocationFromRawParser(portStr, exposeCommand.Location())
+			result.LocationRange = ctx.ParseLocation(portStr, exposeCommand.Location())
 		}
 	}
 
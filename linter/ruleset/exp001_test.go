@@ -67,7 +67,7 @@ func TestValidateExp001(t *testing.T) {
 				Ports: testCase.PortValue,
 			}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateExp001(command).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateExp001(command, nil).IsViolated())
 		})
 	}
 }
@@ -0,0 +1,264 @@
+package ruleset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// TextEdit replaces the text at LocationRange with NewText. Like in the rule validation results, line numbers are
+// 1-based, while character numbers are 0-based byte offsets in the line.
+type TextEdit struct {
+	LocationRange LocationRange
+	NewText       string
+}
+
+// FixFunc produces the text edits, that fix the violation reported in result. rawStr is the content of the Dockerfile.
+// It returns no edits, if the violation cannot be fixed mechanically.
+type FixFunc func(result *RuleValidationResult, rawStr string) []TextEdit
+
+// Fix is the autofix capability of a rule.
+type Fix struct {
+	title   string
+	fixFunc FixFunc
+}
+
+// fixMap stores the fix of each fixable rule by rule ID.
+var (
+	fixMap     = map[string]Fix{} // nolint:gochecknoglobals
+	fixMapLock = sync.RWMutex{}   // nolint:gochecknoglobals
+)
+
+// RegisterFix makes the rule with ruleID fixable by fixFunc. Title is a short, imperative description of the fix.
+// Like NewRule, it's meant to be called in a var declaration next to the rule.
+func RegisterFix(ruleID string, title string, fixFunc FixFunc) Fix {
+	fix := Fix{title: title, fixFunc: fixFunc}
+
+	fixMapLock.Lock()
+	fixMap[ruleID] = fix
+	fixMapLock.Unlock()
+
+	return fix
+}
+
+// getFix returns the fix of the rule with ruleID, if it's fixable.
+func getFix(ruleID string) (Fix, bool) {
+	fixMapLock.RLock()
+	defer fixMapLock.RUnlock()
+
+	fix, ok := fixMap[ruleID]
+
+	return fix, ok
+}
+
+// IsFixable returns true, if the rule has an autofix.
+func (rule *Rule) IsFixable() bool {
+	_, ok := getFix(rule.id)
+
+	return ok
+}
+
+// FixTitle returns the short description of the rule's autofix, or an empty string if it has none.
+func (rule *Rule) FixTitle() string {
+	fix, _ := getFix(rule.id)
+
+	return fix.title
+}
+
+// Fix returns the text edits, that fix the violation reported in result. See FixFunc.
+func (rule *Rule) Fix(result *RuleValidationResult, rawStr string) []TextEdit {
+	fix, ok := getFix(rule.id)
+	if !ok || !result.IsViolated() || result.LocationRange.Start() == nil {
+		return []TextEdit{}
+	}
+
+	return fix.fixFunc(result, rawStr)
+}
+
+// InstructionFix creates a FixFunc, that replaces the whole instruction of the violation with its rewritten version.
+// Rewrite receives the source code of the instruction, including the line continuations, and returns false, if it
+// cannot be fixed.
+func InstructionFix(rewrite func(instruction string) (string, bool)) FixFunc {
+	return func(result *RuleValidationResult, rawStr string) []TextEdit {
+		lineList := strings.Split(rawStr, "\n")
+
+		startLine, endLine, ok := InstructionLineRange(lineList, result.LocationRange.Start().LineNumber())
+		if !ok {
+			return []TextEdit{}
+		}
+
+		instruction := strings.Join(lineList[startLine-1:endLine], "\n")
+
+		// the trigger of an ONBUILD instruction is rewritten on its own, e.g. CMD echo hi of ONBUILD CMD echo hi
+		onbuildPrefix := regexpOnbuildPrefix.FindString(instruction)
+
+		fixedInstruction, ok := rewrite(strings.TrimPrefix(instruction, onbuildPrefix))
+		if !ok || onbuildPrefix+fixedInstruction == instruction {
+			return []TextEdit{}
+		}
+
+		return []TextEdit{{
+			LocationRange: NewLocationRange(startLine, 0, endLine, len(lineList[endLine-1])),
+			NewText:       onbuildPrefix + fixedInstruction,
+		}}
+	}
+}
+
+// InstructionLineRange returns the first and last line of the instruction, that spans over lineNumber, following the
+// line continuation backslashes. Line numbers are 1-based.
+func InstructionLineRange(lineList []string, lineNumber int) (int, int, bool) {
+	if lineNumber < 1 || lineNumber > len(lineList) {
+		return 0, 0, false
+	}
+
+	isContinued := func(line string) bool {
+		return strings.HasSuffix(strings.TrimRight(line, " \t\r"), "\\")
+	}
+
+	startLine := lineNumber
+	for startLine > 1 && isContinued(lineList[startLine-2]) {
+		startLine--
+	}
+
+	endLine := lineNumber
+	for endLine < len(lineList) && isContinued(lineList[endLine-1]) {
+		endLine++
+	}
+
+	return startLine, endLine, true
+}
+
+// ApplyTextEdits applies the edits to content. An edit overlapping with an earlier one is skipped, as it was computed
+// for the original content. It can be applied in the next pass, after re-linting. Returns the edited content and the
+// number of applied edits.
+func ApplyTextEdits(content string, editList []TextEdit) (string, int) {
+	lineOffsetList := []int{0}
+
+	for i, char := range content {
+		if char == '\n' {
+			lineOffsetList = append(lineOffsetList, i+1)
+		}
+	}
+
+	offset := func(location *Location) (int, bool) {
+		if location.LineNumber() < 1 || location.LineNumber() > len(lineOffsetList) {
+			return 0, false
+		}
+
+		result := lineOffsetList[location.LineNumber()-1] + location.CharNumber()
+
+		return result, result <= len(content)
+	}
+
+	type offsetEdit struct {
+		start, end int
+		newText    string
+	}
+
+	offsetEditList := make([]offsetEdit, 0, len(editList))
+
+	for _, edit := range editList {
+		start, okStart := offset(edit.LocationRange.Start())
+		end, okEnd := offset(edit.LocationRange.End())
+
+		if okStart && okEnd && start <= end {
+			offsetEditList = append(offsetEditList, offsetEdit{start: start, end: end, newText: edit.NewText})
+		}
+	}
+
+	sort.SliceStable(offsetEditList, func(i, j int) bool {
+		return offsetEditList[i].start < offsetEditList[j].start
+	})
+
+	strBuilder := strings.Builder{}
+	position := 0
+	appliedCount := 0
+
+	for _, edit := range offsetEditList {
+		if edit.start < position {
+			continue
+		}
+
+		strBuilder.WriteString(content[position:edit.start])
+		strBuilder.WriteString(edit.newText)
+
+		position = edit.end
+		appliedCount++
+	}
+
+	strBuilder.WriteString(content[position:])
+
+	return strBuilder.String(), appliedCount
+}
+
+// regexpOnbuildPrefix matches the ONBUILD keyword of an instruction, that triggers another one, e.g. ONBUILD RUN make.
+var regexpOnbuildPrefix = regexp.MustCompile(`(?i)^\s*ONBUILD(?:\s|\\\n)+`) // nolint:gochecknoglobals
+
+// regexpInstruction splits an instruction into its keyword and arguments.
+var regexpInstruction = regexp.MustCompile(`^\s*(\S+)\s+((?s).*)$`) // nolint:gochecknoglobals
+
+// splitInstruction returns the keyword and the arguments of the instruction, joining the continuation lines.
+func splitInstruction(instruction string) (string, string, bool) {
+	matchList := regexpInstruction.FindStringSubmatch(instruction)
+	if matchList == nil {
+		return "", "", false
+	}
+
+	argStr := strings.TrimSpace(strings.ReplaceAll(matchList[2], "\\\n", ""))
+
+	return matchList[1], argStr, argStr != ""
+}
+
+// marshalJSONString quotes str as a JSON string, without escaping HTML characters like "&".
+func marshalJSONString(str string) (string, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(str); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// regexpBashSeparator matches the operators separating bash commands.
+var regexpBashSeparator = regexp.MustCompile(`&&|\|\||;|\|`) // nolint:gochecknoglobals
+
+// insertOption inserts option after each match of commandRegexp, whose command does not have any of the equivalent
+// options yet. The first submatch of commandRegexp is the binary, e.g. apt-get.
+func insertOption(instruction string, commandRegexp *regexp.Regexp, option string,
+	equivalentOptionListFn func(bin string) []string) string {
+	matchList := commandRegexp.FindAllStringSubmatchIndex(instruction, -1)
+
+	// backwards, so the indices of the earlier matches stay valid
+	for i := len(matchList) - 1; i >= 0; i-- {
+		match := matchList[i]
+		bin := instruction[match[2]:match[3]]
+
+		commandEnd := len(instruction)
+		if separatorIndex := regexpBashSeparator.FindStringIndex(instruction[match[1]:]); separatorIndex != nil {
+			commandEnd = match[1] + separatorIndex[0]
+		}
+
+		hasOption := false
+
+		for _, field := range strings.Fields(instruction[match[0]:commandEnd]) {
+			if Utils.EqualsEither(field, equivalentOptionListFn(bin)) {
+				hasOption = true
+			}
+		}
+
+		if !hasOption {
+			instruction = instruction[:match[1]] + " " + option + instruction[match[1]:]
+		}
+	}
+
+	return instruction
+}
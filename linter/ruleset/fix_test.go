@@ -0,0 +1,250 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// nolint:funlen
+func TestRule_Fix(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		RuleID        string
+		RawStr        string
+		LineNumber    int
+		ExpectedEdits []RuleSet.TextEdit
+	}{
+		{
+			Name:   "RUN009 in a single line RUN.",
+			RuleID: "RUN009", RawStr: "FROM ubuntu:20.04\nRUN apt-get update && apt-get install vim", LineNumber: 2,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(2, 0, 2, 41),
+				NewText:       "RUN apt-get update && apt-get install -y vim",
+			}},
+		},
+		{
+			Name:   "RUN009 only where -y is missing.",
+			RuleID: "RUN009", RawStr: "RUN apt-get install -y vim && apt-get remove \\\n    curl", LineNumber: 2,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 2, 8),
+				NewText:       "RUN apt-get install -y vim && apt-get remove -y \\\n    curl",
+			}},
+		},
+		{
+			Name:   "RUN010 in a multi-line RUN.",
+			RuleID: "RUN010", RawStr: "FROM ubuntu:20.04\nRUN apt-get update && \\\n    apt install -y vim\n",
+			LineNumber: 2,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(2, 0, 3, 22),
+				NewText:       "RUN apt-get update && \\\n    apt install --no-install-recommends -y vim",
+			}},
+		},
+		{
+			Name:   "CMD001 without shell features.",
+			RuleID: "CMD001", RawStr: "CMD go run main.go", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 1, 18),
+				NewText:       `CMD ["go", "run", "main.go"]`,
+			}},
+		},
+		{
+			Name:   "ENT001 with shell features.",
+			RuleID: "ENT001", RawStr: "ENTRYPOINT echo $HOME && date", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 1, 29),
+				NewText:       `ENTRYPOINT ["/bin/sh", "-c", "echo $HOME && date"]`,
+			}},
+		},
+		{
+			Name:   "ENT001 with invalid JSON.",
+			RuleID: "ENT001", RawStr: `ENTRYPOINT ["/bin/bash" "date"`, LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{},
+		},
+		{
+			Name:   "MTR001.",
+			RuleID: "MTR001", RawStr: `MAINTAINER John "JD" Doe <jd@example.com>`, LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 1, 41),
+				NewText:       `LABEL maintainer="John \"JD\" Doe <jd@example.com>"`,
+			}},
+		},
+		{
+			Name:   "CPY001.",
+			RuleID: "CPY001", RawStr: "COPY -chmod=644 ---chown=user:group src dst/", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 1, 44),
+				NewText:       "COPY --chmod=644 --chown=user:group src dst/",
+			}},
+		},
+		{
+			Name:   "CPY004.",
+			RuleID: "CPY004", RawStr: "COPY go.mod go.sum /app  ", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 1, 25),
+				NewText:       "COPY go.mod go.sum /app/",
+			}},
+		},
+		{
+			Name:   "CPY004 with JSON form.",
+			RuleID: "CPY004", RawStr: `COPY ["go.mod", "go.sum", "/app"]`, LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{},
+		},
+		{
+			Name:   "ADD001.",
+			RuleID: "ADD001", RawStr: "FROM golang\nadd --chown=app \\\n    src /app/", LineNumber: 2,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(2, 0, 3, 13),
+				NewText:       "COPY --chown=app \\\n    src /app/",
+			}},
+		},
+		{
+			Name:   "ADD006.",
+			RuleID: "ADD006", RawStr: "ADD app.tar.gz config.tar.gz /app", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 1, 33),
+				NewText:       "ADD app.tar.gz config.tar.gz /app/",
+			}},
+		},
+		{
+			Name:   "ENV001.",
+			RuleID: "ENV001", RawStr: "FROM golang\nENV GREETING hello   $USER", LineNumber: 2,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(2, 0, 2, 26),
+				NewText:       "ENV GREETING=\"hello   $USER\"",
+			}},
+		},
+		{
+			Name:   "ENV001 with quotes.",
+			RuleID: "ENV001", RawStr: "ENV GREETING \"hello\" world", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{},
+		},
+		{
+			Name:   "WKD001 in the first WORKDIR of the stage.",
+			RuleID: "WKD001", RawStr: "FROM golang\nWORKDIR /go\nFROM alpine\nWORKDIR app", LineNumber: 4,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(4, 0, 4, 11),
+				NewText:       "WORKDIR /app",
+			}},
+		},
+		{
+			Name:   "WKD001 after other WORKDIRs.",
+			RuleID: "WKD001", RawStr: "FROM golang\nWORKDIR /go\nRUN date\nworkdir src/../app\nWORKDIR bin", LineNumber: 5,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(5, 0, 5, 11),
+				NewText:       "WORKDIR /go/app/bin",
+			}},
+		},
+		{
+			Name:   "WKD001 with a variable.",
+			RuleID: "WKD001", RawStr: "FROM golang\nWORKDIR $HOME\nWORKDIR app", LineNumber: 3,
+			ExpectedEdits: []RuleSet.TextEdit{},
+		},
+		{
+			Name:   "CMD001 of an ONBUILD trigger.",
+			RuleID: "CMD001", RawStr: "ONBUILD \\\n    CMD go run main.go", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{{
+				LocationRange: RuleSet.NewLocationRange(1, 0, 2, 22),
+				NewText:       "ONBUILD \\\n    CMD [\"go\", \"run\", \"main.go\"]",
+			}},
+		},
+		{
+			Name:   "WKD001 of an ONBUILD trigger.",
+			RuleID: "WKD001", RawStr: "FROM golang\nONBUILD WORKDIR app", LineNumber: 2,
+			ExpectedEdits: []RuleSet.TextEdit{},
+		},
+		{
+			Name:   "Not fixable rule.",
+			RuleID: "STS001", RawStr: "FROM golang", LineNumber: 1,
+			ExpectedEdits: []RuleSet.TextEdit{},
+		},
+		{
+			Name:   "Line out of range.",
+			RuleID: "RUN009", RawStr: "RUN apt-get install vim", LineNumber: 3,
+			ExpectedEdits: []RuleSet.TextEdit{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := RuleSet.Get().GetRuleByName(testCase.RuleID, nil)
+			result := RuleSet.NewRuleValidationResult(&rule, true, "",
+				RuleSet.NewLocationRange(testCase.LineNumber, 0, testCase.LineNumber, 0))
+
+			assert.Equal(t, testCase.ExpectedEdits, rule.Fix(result, testCase.RawStr))
+			assert.Equal(t, testCase.RuleID != "STS001", rule.IsFixable())
+		})
+	}
+}
+
+func TestRule_FixNotViolated(t *testing.T) {
+	t.Parallel()
+
+	rule := RuleSet.Get().GetRuleByName("CMD001", nil)
+	result := RuleSet.NewRuleValidationResult(&rule, false, "", RuleSet.NewLocationRange(1, 0, 1, 0))
+
+	assert.Empty(t, rule.Fix(result, "CMD go run main.go"))
+}
+
+func TestApplyTextEdits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name            string
+		Content         string
+		EditList        []RuleSet.TextEdit
+		ExpectedContent string
+		ExpectedCount   int
+	}{
+		{
+			Name:    "Edits in reverse order.",
+			Content: "FROM golang\nCMD date\nWORKDIR app\n",
+			EditList: []RuleSet.TextEdit{
+				{LocationRange: RuleSet.NewLocationRange(3, 0, 3, 11), NewText: "WORKDIR /app"},
+				{LocationRange: RuleSet.NewLocationRange(2, 0, 2, 8), NewText: `CMD ["date"]`},
+			},
+			ExpectedContent: "FROM golang\nCMD [\"date\"]\nWORKDIR /app\n",
+			ExpectedCount:   2,
+		},
+		{
+			Name:    "Overlapping edit is skipped.",
+			Content: "RUN apt-get install vim",
+			EditList: []RuleSet.TextEdit{
+				{LocationRange: RuleSet.NewLocationRange(1, 0, 1, 23), NewText: "RUN apt-get install -y vim"},
+				{LocationRange: RuleSet.NewLocationRange(1, 0, 1, 23), NewText: "RUN apt-get install --no-install-recommends vim"},
+			},
+			ExpectedContent: "RUN apt-get install -y vim",
+			ExpectedCount:   1,
+		},
+		{
+			Name:    "Out of range edit is skipped.",
+			Content: "FROM golang",
+			EditList: []RuleSet.TextEdit{
+				{LocationRange: RuleSet.NewLocationRange(2, 0, 2, 3), NewText: "CMD"},
+			},
+			ExpectedContent: "FROM golang",
+			ExpectedCount:   0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			content, count := RuleSet.ApplyTextEdits(testCase.Content, testCase.EditList)
+
+			assert.Equal(t, testCase.ExpectedContent, content)
+			assert.Equal(t, testCase.ExpectedCount, count)
+		})
+	}
+}
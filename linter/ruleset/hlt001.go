@@ -0,0 +1,37 @@
+package ruleset
+
+import (
+	"strconv"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+// HLT -> HEALTHCHECK.
+var _ = NewRule("HLT001", "Stage should have at most one HEALTHCHECK.", "Only the last HEALTHCHECK of a stage "+
+	"takes effect, so the previous ones are either leftovers or mistakes.",
+	ValWarning, ValidateHlt001)
+
+func ValidateHlt001(stage instructions.Stage) RuleValidationResult {
+	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
+	previousLineNumber := 0
+
+	for _, command := range stage.Commands {
+		healthCheckCommand, ok := command.(*instructions.HealthCheckCommand)
+		if !ok {
+			continue
+		}
+
+		if previousLineNumber > 0 {
+			result.SetViolated()
+			result.message = "HEALTHCHECK overrides the one on line " + strconv.Itoa(previousLineNumber) + "."
+			result.LocationRange = LocationRangeFromCommand(healthCheckCommand)
+
+			return result
+		}
+
+		locationRange := LocationRangeFromCommand(healthCheckCommand)
+		previousLineNumber = locationRange.Start().LineNumber()
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateHlt001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "One HEALTHCHECK.",
+			DocsContext: "FROM nginx:1.21\nHEALTHCHECK CMD curl -f http://localhost/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Two HEALTHCHECKs.",
+			DocsContext: "FROM nginx:1.21\nHEALTHCHECK CMD curl -f http://localhost/\nHEALTHCHECK CMD wget -qO- localhost",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "One HEALTHCHECK per stage.",
+			DocsContext: "FROM nginx:1.21 AS base\nHEALTHCHECK NONE\nFROM base\nHEALTHCHECK CMD curl -f http://localhost/",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("HLT001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, _ := parseDockerfile(t, testCase.DocsContext)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateHlt001(stageList[0]).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package ruleset
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	Parser "github.com/cremindes/whalelint/parser"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("HLT002", "HEALTHCHECK options must be valid durations and retries.", "The --interval, "+
+	"--timeout and --start-period options are durations of at least 1ms, e.g. 30s or 1m30s, while --retries is a "+
+	"positive integer. An invalid HEALTHCHECK fails the build.",
+	ValError, ValidateHlt002)
+
+// As buildkit cannot build a command from an invalid HEALTHCHECK, it's validated as an invalid instruction.
+func ValidateHlt002(invalidInstruction *Parser.InvalidInstruction, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: BKRangeSliceToLocationRange(invalidInstruction.Location()),
+	}
+
+	if flag, message := invalidHealthcheckOption(invalidInstruction); message != "" {
+		result.SetViolated()
+		result.message = message
+		result.LocationRange = ctx.ParseLocation(flag, invalidInstruction.Location())
+	}
+
+	return result
+}
+
+// invalidHealthcheckOption returns the first invalid option of the HEALTHCHECK instruction and why it's invalid, or
+// empty strings, if it's not a HEALTHCHECK or its options are valid.
+func invalidHealthcheckOption(invalidInstruction *Parser.InvalidInstruction) (string, string) {
+	if invalidInstruction.Keyword() != "HEALTHCHECK" {
+		return "", ""
+	}
+
+	for _, flag := range invalidInstruction.Node.Flags {
+		name, value := Utils.SplitKeyValue(strings.TrimPrefix(flag, "--"), '=')
+
+		if message := healthcheckOptionError(name, value); message != "" {
+			return flag, message
+		}
+	}
+
+	return "", ""
+}
+
+// healthcheckOptionError returns, why the value of the HEALTHCHECK option is invalid, or an empty string.
+func healthcheckOptionError(name string, value string) string {
+	switch name {
+	case "interval", "timeout", "start-period":
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return "--" + name + " is not a valid duration, e.g. 30s."
+		}
+
+		if duration < time.Millisecond {
+			return "--" + name + " must be at least 1ms."
+		}
+	case "retries":
+		if retries, err := strconv.Atoi(value); err != nil || retries < 1 {
+			return "--retries must be a positive integer."
+		}
+	default:
+		return "--" + name + " is not a HEALTHCHECK option."
+	}
+
+	return ""
+}
@@ -0,0 +1,61 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+func TestValidateHlt002(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "Valid options.",
+			DocsContext: "FROM nginx:1.21\n" +
+				"HEALTHCHECK --interval=30s --timeout=3s --start-period=1m --retries=3 CMD curl -f http://localhost/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Duration without a unit.",
+			DocsContext: "FROM nginx:1.21\nHEALTHCHECK --interval=30 CMD curl -f http://localhost/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Zero retries.",
+			DocsContext: "FROM nginx:1.21\nHEALTHCHECK --retries=0 CMD curl -f http://localhost/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Unknown option.",
+			DocsContext: "FROM nginx:1.21\nHEALTHCHECK --period=30s CMD curl -f http://localhost/",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("HLT002", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			_, ctx := parseDockerfile(t, testCase.DocsContext)
+
+			isViolated := false
+			for _, invalidInstruction := range Parser.ParseInvalidInstructionList(testCase.DocsContext) {
+				isViolated = isViolated || RuleSet.ValidateHlt002(invalidInstruction, ctx).IsViolated()
+			}
+
+			assert.Equal(t, testCase.IsViolation, isViolated)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("HLT003", "Use the exec form of HEALTHCHECK in images without a shell.", "The shell form, e.g. "+
+	"`HEALTHCHECK CMD curl -f http://localhost/`, is run by `/bin/sh -c`, which is missing from scratch and "+
+	"distroless images, so the health check always fails. Use the exec form instead, e.g. "+
+	"`HEALTHCHECK CMD [\"/app\", \"healthcheck\"]`.",
+	ValError, ValidateHlt003)
+
+func ValidateHlt003(stage instructions.Stage) RuleValidationResult {
+	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
+
+	if !isShellLessImage(stage.BaseName) {
+		return result
+	}
+
+	for _, command := range stage.Commands {
+		healthCheckCommand, ok := command.(*instructions.HealthCheckCommand)
+		if !ok || healthCheckCommand.Health == nil || len(healthCheckCommand.Health.Test) == 0 {
+			continue
+		}
+
+		if healthCheckCommand.Health.Test[0] == "CMD-SHELL" {
+			result.SetViolated()
+			result.LocationRange = LocationRangeFromCommand(healthCheckCommand)
+		}
+	}
+
+	return result
+}
+
+// isShellLessImage tells, whether the image lacks /bin/sh, i.e. it's scratch or a distroless image.
+func isShellLessImage(imageName string) bool {
+	return strings.EqualFold(imageName, "scratch") || strings.Contains(imageName, "distroless")
+}
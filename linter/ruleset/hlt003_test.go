@@ -0,0 +1,54 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateHlt003(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "Shell form in an image with a shell.",
+			DocsContext: "FROM nginx:1.21\nHEALTHCHECK CMD curl -f http://localhost/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Shell form in scratch.",
+			DocsContext: "FROM scratch\nCOPY app /app\nHEALTHCHECK CMD /app healthcheck",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Shell form in distroless.",
+			DocsContext: "FROM gcr.io/distroless/static:nonroot\nHEALTHCHECK CMD /app healthcheck",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Exec form in distroless.",
+			DocsContext: "FROM gcr.io/distroless/static:nonroot\nHEALTHCHECK CMD [\"/app\", \"healthcheck\"]",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("HLT003", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, _ := parseDockerfile(t, testCase.DocsContext)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateHlt003(stageList[len(stageList)-1]).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package ruleset
+
+import (
+	"strings"
+
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+// IGN -> Ignore, i.e. inline suppression directives.
+var _ = NewRule("IGN001", "Suppression directive should suppress something.", "Unused inline suppressions, "+
+	"e.g. `# whalelint:ignore RUN004` without a RUN004 violation in its range, tend to accumulate as the Dockerfile "+
+	"changes and hide future violations. Remove them or narrow down their rule ID list.",
+	ValWarning, ValidateIgn001)
+
+func ValidateIgn001(suppression *Parser.Suppression) RuleValidationResult {
+	result := RuleValidationResult{isViolated: false, LocationRange: NewLocationFrom4Int(suppression.Location)}
+
+	if !suppression.IsUsed() {
+		result.SetViolated()
+	}
+
+	if unusedRuleIDList := suppression.UnusedRuleIDList(); len(unusedRuleIDList) > 0 {
+		result.SetViolated()
+		result.message = "Suppression of " + strings.Join(unusedRuleIDList, ", ") + " is unused."
+	}
+
+	return result
+}
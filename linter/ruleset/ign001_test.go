@@ -0,0 +1,66 @@
+package ruleset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+func TestValidateIgn001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation     bool
+		SuppressedRules []string
+		ExampleName     string
+		DocsContext     string
+	}{
+		{
+			IsViolation:     false,
+			SuppressedRules: []string{"RUN004"},
+			ExampleName:     "Used suppression.",
+			DocsContext:     "`FROM` golang:1.17\n# whalelint:ignore RUN004\n`RUN` sudo make install",
+		},
+		{
+			IsViolation:     true,
+			SuppressedRules: []string{},
+			ExampleName:     "Unused suppression.",
+			DocsContext:     "`FROM` golang:1.17\n# whalelint:ignore RUN004\n`RUN` make install",
+		},
+		{
+			IsViolation:     true,
+			SuppressedRules: []string{"RUN004"},
+			ExampleName:     "Partially used suppression.",
+			DocsContext:     "`FROM` golang:1.17\n# whalelint:ignore RUN004,RUN009\n`RUN` sudo make install",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("IGN001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			dockerfileStr := strings.ReplaceAll(testCase.DocsContext, "`", "")
+
+			suppressionList := Parser.ParseSuppressionList(dockerfileStr, []parser.Range{
+				{Start: parser.Position{Line: 1}, End: parser.Position{Line: 1}},
+				{Start: parser.Position{Line: 3}, End: parser.Position{Line: 3}},
+			})
+			assert.Equal(t, 1, len(suppressionList))
+
+			for _, ruleID := range testCase.SuppressedRules {
+				assert.True(t, suppressionList[0].Suppresses(ruleID, 3))
+			}
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateIgn001(suppressionList[0]).IsViolated())
+		})
+	}
+}
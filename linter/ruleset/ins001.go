@@ -0,0 +1,32 @@
+package ruleset
+
+import (
+	"strings"
+
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+// INS -> Instruction.
+var _ = NewRule("INS001", "Instruction must be valid.", "Docker fails to build an instruction, that it cannot "+
+	"parse, e.g. because of an unknown flag or a missing argument. WhaleLint cannot lint it either, so it's "+
+	"reported instead.",
+	ValError, ValidateIns001)
+
+func ValidateIns001(invalidInstruction *Parser.InvalidInstruction) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: BKRangeSliceToLocationRange(invalidInstruction.Location()),
+	}
+
+	// the invalid HEALTHCHECK options, ONBUILD triggers and unknown flags are reported by HLT002, ONB001 and INS002
+	if _, message := invalidHealthcheckOption(invalidInstruction); message != "" ||
+		invalidOnbuildTrigger(invalidInstruction) != "" || unknownFlag(invalidInstruction) != "" {
+		return result
+	}
+
+	result.SetViolated()
+	result.message = invalidInstruction.Keyword() + " cannot be parsed: " +
+		strings.TrimSuffix(invalidInstruction.Err.Error(), ".") + "."
+
+	return result
+}
@@ -0,0 +1,58 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+func TestValidateIns001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: true,
+			ExampleName: "Misspelled flag.",
+			DocsContext: "FROM golang:1.17\nCOPY --chwon=app:app . /app/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Missing argument.",
+			DocsContext: "FROM golang:1.17\nWORKDIR",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Invalid HEALTHCHECK, see HLT002.",
+			DocsContext: "FROM golang:1.17\nHEALTHCHECK --interval=5 CMD [\"/app\", \"healthcheck\"]",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Unknown flag, see INS002.",
+			DocsContext: "FROM golang:1.17\nCOPY --parents src/*/go.mod /app/",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("INS001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			invalidInstructionList := Parser.ParseInvalidInstructionList(testCase.DocsContext)
+			assert.Len(t, invalidInstructionList, 1)
+
+			result := RuleSet.ValidateIns001(invalidInstructionList[0])
+			assert.Equal(t, testCase.IsViolation, result.IsViolated())
+			assert.Equal(t, 2, result.Location().Start().LineNumber())
+		})
+	}
+}
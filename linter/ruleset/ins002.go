@@ -0,0 +1,41 @@
+package ruleset
+
+import (
+	"strings"
+
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+var _ = NewRule("INS002", "Instruction flags should be known to WhaleLint.", "The buildkit parser of WhaleLint "+
+	"does not know every flag of the newer Dockerfile syntax, so it cannot lint an instruction, that has one. Docker "+
+	"may still build it, so it's only a warning. A flag, that resembles a known one, is taken for a typo, see INS001.",
+	ValWarning, ValidateIns002)
+
+func ValidateIns002(invalidInstruction *Parser.InvalidInstruction, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: BKRangeSliceToLocationRange(invalidInstruction.Location()),
+	}
+
+	if flag := unknownFlag(invalidInstruction); flag != "" {
+		result.SetViolated()
+		result.message = invalidInstruction.Keyword() + " --" + flag + " is unknown to WhaleLint, so the " +
+			"instruction is not linted."
+		result.LocationRange = ctx.ParseLocation("--"+flag, invalidInstruction.Location())
+	}
+
+	return result
+}
+
+// unknownFlag returns the name of the flag, that buildkit does not know, or an empty string. A flag, that buildkit
+// suggests a known one for, e.g. --chwon, is not returned, as it's rather a typo.
+func unknownFlag(invalidInstruction *Parser.InvalidInstruction) string {
+	const prefix = "unknown flag: "
+
+	message := invalidInstruction.Err.Error()
+	if !strings.HasPrefix(message, prefix) || strings.Contains(message, "(did you mean ") {
+		return ""
+	}
+
+	return strings.TrimPrefix(message, prefix)
+}
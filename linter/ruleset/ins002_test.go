@@ -0,0 +1,61 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+func TestValidateIns002(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: true,
+			ExampleName: "Unknown flag.",
+			DocsContext: "FROM golang:1.17\nCOPY --parents src/*/go.mod /app/",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Misspelled flag, see INS001.",
+			DocsContext: "FROM golang:1.17\nCOPY --chwon=app:app . /app/",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Missing argument, see INS001.",
+			DocsContext: "FROM golang:1.17\nWORKDIR",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("INS002", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			invalidInstructionList := Parser.ParseInvalidInstructionList(testCase.DocsContext)
+			assert.Len(t, invalidInstructionList, 1)
+
+			ctx := RuleSet.NewContext("", testCase.DocsContext)
+
+			result := RuleSet.ValidateIns002(invalidInstructionList[0], ctx)
+			assert.Equal(t, testCase.IsViolation, result.IsViolated())
+
+			if testCase.IsViolation {
+				assert.Equal(t, 2, result.Location().Start().LineNumber())
+				assert.Equal(t, 5, result.Location().Start().CharNumber())
+				assert.Equal(t, "COPY --parents is unknown to WhaleLint, so the instruction is not linted.",
+					result.Message())
+			}
+		})
+	}
+}
@@ -7,8 +7,6 @@ import (
 
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
-
-	Parser "github.com/cremindes/whalelint/parser"
 )
 
 type LocationRange struct {
@@ -149,38 +147,6 @@ func NewLocationRange(startLine, startChar, endLine, endChar int) LocationRange
 	}
 }
 
-func ParseLocationFromRawParser(str string, window []parser.Range) LocationRange {
-	if !Parser.RawParser.IsInitialized() {
-		return BKRangeSliceToLocationRange(window)
-	}
-
-	location := NewLocationFrom4Int(
-		Parser.RawParser.StringLocation(str, window),
-	)
-
-	if location.Start().LineNumber() == -1 {
-		return BKRangeSliceToLocationRange(window)
-	}
-
-	return location
-}
-
-func ParseLocationSliceFromRawParser(strSlice []string, window []parser.Range) []LocationRange {
-	if !Parser.RawParser.IsInitialized() {
-		return []LocationRange{BKRangeSliceToLocationRange(window)}
-	}
-
-	location := NewLocationFrom4IntSlice(
-		Parser.RawParser.StringSliceLocation(strSlice, window),
-	)
-
-	if location[0].Start().LineNumber() == -1 {
-		return []LocationRange{BKRangeSliceToLocationRange(window)}
-	}
-
-	return location
-}
-
 func NewLocationFrom4Int(locationRange [4]int) LocationRange {
 	return LocationRange{
 		start: &Location{locationRange[0], locationRange[1]},
@@ -1,15 +1,31 @@
 package ruleset
 
 import (
+	"strings"
+
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 )
 
 var _ = NewRule("MTR001", "MAINTAINER is deprecated. Use a LABEL instead.", "",
 	ValDeprecation, ValidateMtr001)
 
-func ValidateMtr001(maintainerCommand *instructions.MaintainerCommand) RuleValidationResult {
+var _ = RegisterFix("MTR001", "Replace MAINTAINER with LABEL maintainer=\"...\".", InstructionFix(FixMtr001))
+
+func ValidateMtr001(maintainerCommand *instructions.MaintainerCommand, ctx *Context) RuleValidationResult {
 	return RuleValidationResult{
 		isViolated:    true,
-		LocationRange: ParseLocationFromRawParser(maintainerCommand.String(), maintainerCommand.Location()),
+		LocationRange: ctx.ParseLocation(maintainerCommand.String(), maintainerCommand.Location()),
 	}
 }
+
+// FixMtr001 replaces "MAINTAINER name" with "LABEL maintainer="name"".
+func FixMtr001(instruction string) (string, bool) {
+	_, maintainer, ok := splitInstruction(instruction)
+	if !ok {
+		return "", false
+	}
+
+	maintainer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(maintainer)
+
+	return `LABEL maintainer="` + maintainer + `"`, true
+}
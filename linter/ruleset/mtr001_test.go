@@ -43,7 +43,7 @@ func TestValidateMtr001(t *testing.T) {
 				maintainerCommand, err := RuleSet.NewMaintainerCommand("John Doe <john.doe@example.com>")
 				assert.Nil(t, err)
 
-				assert.Equal(t, testCase.IsViolation, RuleSet.ValidateMtr001(maintainerCommand).IsViolated())
+				assert.Equal(t, testCase.IsViolation, RuleSet.ValidateMtr001(maintainerCommand, nil).IsViolated())
 			} else {
 				assert.Equal(t, testCase.IsViolation, testCase.HasMaintainer)
 			}
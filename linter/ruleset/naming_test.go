@@ -161,7 +161,8 @@ func (rncm *RuleNameCheckMap) update(key, filename,
 
 func filterAstNewRuleCall(decl ast.Decl) *ast.CallExpr {
 	if genDecl, isGenDecl := decl.(*ast.GenDecl); isGenDecl { //nolint:nestif
-		if valueSpec, isValueSpec := genDecl.Specs[0].(*ast.ValueSpec); isValueSpec {
+		// values are missing, e.g. for embedded files
+		if valueSpec, isValueSpec := genDecl.Specs[0].(*ast.ValueSpec); isValueSpec && len(valueSpec.Values) > 0 {
 			if callExp, isCallExp := valueSpec.Values[0].(*ast.CallExpr); isCallExp {
 				if ident, isIdent := callExp.Fun.(*ast.Ident); isIdent {
 					if ident.Name == "NewRule" {
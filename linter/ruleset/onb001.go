@@ -0,0 +1,69 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+
+	Parser "github.com/cremindes/whalelint/parser"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// ONB -> ONBUILD.
+var _ = NewRule("ONB001", "ONBUILD must not trigger FROM, MAINTAINER or ONBUILD.", "These instructions are not "+
+	"allowed as ONBUILD triggers, so the build fails. The other triggers are validated as the instructions of the "+
+	"stage.",
+	ValError, ValidateOnb001)
+
+// As buildkit cannot build a command from an invalid ONBUILD, it's validated as an invalid instruction.
+func ValidateOnb001(invalidInstruction *Parser.InvalidInstruction, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: BKRangeSliceToLocationRange(invalidInstruction.Location()),
+	}
+
+	if trigger := invalidOnbuildTrigger(invalidInstruction); trigger != "" {
+		result.SetViolated()
+		result.message = trigger + " is not allowed as an ONBUILD trigger."
+		result.LocationRange = onbuildTriggerLocation(invalidInstruction, ctx)
+	}
+
+	return result
+}
+
+// onbuildTriggerLocation locates the keyword of the trigger instruction, as written, after the ONBUILD keyword, e.g.
+// the second ONBUILD of ONBUILD ONBUILD RUN make.
+func onbuildTriggerLocation(invalidInstruction *Parser.InvalidInstruction, ctx *Context) LocationRange {
+	window := invalidInstruction.Location()
+
+	fieldList := strings.Fields(invalidInstruction.Node.Original)
+	if len(fieldList) < 2 || len(window) == 0 || !ctx.HasSource() { // nolint:gomnd
+		return BKRangeSliceToLocationRange(window)
+	}
+
+	keywordLocation := ctx.ParseLocation(fieldList[0], window)
+
+	triggerWindow := append([]parser.Range{}, window...)
+	triggerWindow[0].Start = parser.Position{
+		Line:      keywordLocation.End().LineNumber(),
+		Character: keywordLocation.End().CharNumber(),
+	}
+
+	return ctx.ParseLocation(fieldList[1], triggerWindow)
+}
+
+// invalidOnbuildTrigger returns the trigger instruction of the ONBUILD instruction, if it's not allowed, or an empty
+// string.
+func invalidOnbuildTrigger(invalidInstruction *Parser.InvalidInstruction) string {
+	node := invalidInstruction.Node
+	if invalidInstruction.Keyword() != "ONBUILD" || node.Next == nil || len(node.Next.Children) == 0 {
+		return ""
+	}
+
+	trigger := strings.ToUpper(node.Next.Children[0].Value)
+	if !Utils.EqualsEither(trigger, []string{"FROM", "MAINTAINER", "ONBUILD"}) {
+		return ""
+	}
+
+	return trigger
+}
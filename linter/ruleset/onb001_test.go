@@ -0,0 +1,71 @@
+package ruleset_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+func TestValidateOnb001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "ONBUILD of COPY.",
+			DocsContext: "FROM golang:1.17\nONBUILD COPY . /app",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ONBUILD of FROM.",
+			DocsContext: "FROM golang:1.17\nONBUILD FROM alpine:3.14",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ONBUILD of MAINTAINER.",
+			DocsContext: "FROM golang:1.17\nONBUILD MAINTAINER dev@example.com",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "ONBUILD of ONBUILD.",
+			DocsContext: "FROM golang:1.17\nONBUILD ONBUILD RUN make",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("ONB001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := RuleSet.NewContext("", testCase.DocsContext)
+
+			isViolated := false
+
+			for _, invalidInstruction := range Parser.ParseInvalidInstructionList(testCase.DocsContext) {
+				result := RuleSet.ValidateOnb001(invalidInstruction, ctx)
+				if !result.IsViolated() {
+					continue
+				}
+
+				isViolated = true
+
+				// the trigger keyword is located, not the ONBUILD
+				assert.Equal(t, RuleSet.NewLocationRange(2, 8, 2, 8+len(strings.Fields(testCase.DocsContext)[3])),
+					*result.Location())
+			}
+
+			assert.Equal(t, testCase.IsViolation, isViolated)
+		})
+	}
+}
@@ -4,16 +4,16 @@ package ruleset
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
 	log "github.com/sirupsen/logrus"
 )
 
-var ruleMapWriteLock = sync.RWMutex{} // nolint:gochecknoglobals
-
 // Severity type represents a severity, with an int level and a String function.
 type Severity int
 
@@ -54,18 +54,8 @@ func (severity Severity) MarshalJSON() ([]byte, error) {
 }
 
 func (severity *Severity) UnmarshalJSON(data []byte) error {
-	switch strings.Trim(string(data), "\"") {
-	case "Deprecation":
-		*severity = ValDeprecation
-	case "Error":
-		*severity = ValError
-	case "Info":
-		*severity = ValInfo
-	case "Warning":
-		*severity = ValWarning
-	case "Unknown":
-		*severity = ValUnknown
-	default:
+	parsedSeverity, err := ParseSeverity(strings.Trim(string(data), "\""))
+	if err != nil {
 		err := &json.UnmarshalTypeError{
 			Value:  string(data),
 			Type:   reflect.TypeOf(data),
@@ -77,9 +67,41 @@ func (severity *Severity) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to unmarshal Severity: %w", err)
 	}
 
+	*severity = parsedSeverity
+
 	return nil
 }
 
+var ErrInvalidSeverity = errors.New("invalid severity")
+
+// ParseSeverity converts a severity name, like "Error" or "warning", into a Severity. It's case-insensitive.
+func ParseSeverity(str string) (Severity, error) {
+	for _, severity := range GetSeverityList() {
+		if strings.EqualFold(str, severity.String()) {
+			return severity, nil
+		}
+	}
+
+	return ValUnknown, fmt.Errorf("%w: \"%s\"", ErrInvalidSeverity, str)
+}
+
+// IsAtLeast returns true, if severity is at least as severe as threshold. The order is the one of GetSeverityList,
+// i.e. Error > Warning > Info > Deprecation > Unknown.
+func (severity Severity) IsAtLeast(threshold Severity) bool {
+	severityList := GetSeverityList()
+
+	for _, item := range severityList {
+		switch item {
+		case severity:
+			return true
+		case threshold:
+			return false
+		}
+	}
+
+	return false
+}
+
 // DocsReference returns an official reference link connected to the rule itself, most likely directly linking to a
 // Docker documentation webpage.
 func (rule *Rule) DocsReference() DocsReference {
@@ -92,72 +114,88 @@ func (rule *Rule) DocsReference() DocsReference {
 }
 
 // Rule represents a Dockerfile lint validation rule.
-// It has the basic id, definition, description, severity attributes and a validation function as an interface.
-// For further details on validateFunc, please see Validate how it is utilized.
+// It has the basic id, definition, description, severity attributes and a validator of the Dockerfile AST nodes of a
+// single kind. For further details on the validator, please see ValidatorOf.
 type Rule struct {
-	id             string
-	definition     string
-	description    string
-	severity       Severity
-	validationFunc interface{}
+	id          string
+	definition  string
+	description string
+	severity    Severity
+	options     RuleOptions
+	validator   Validator
 }
 
-// Validation calls the the rule's validationFunc validation function
-// in the correct form, after converting from interface{} to the concrete type.
+// RuleOptions holds the per-rule options, e.g. set through the config file.
+// A rule receives them through the Context, if its validation function has a Context second parameter.
+type RuleOptions map[string]interface{}
+
+// StringSlice returns the option value under key as a string slice. Non-string items are skipped.
+func (ruleOptions RuleOptions) StringSlice(key string) []string {
+	result := make([]string, 0)
+
+	valueSlice, ok := ruleOptions[key].([]interface{})
+	if !ok {
+		return result
+	}
+
+	for _, value := range valueSlice {
+		if str, ok := value.(string); ok {
+			result = append(result, str)
+		}
+	}
+
+	return result
+}
+
+// Validate validates the Dockerfile AST node with the rule's validator in ctx, extended with the rule's options. A node
+// of another kind is not validated, i.e. it gives a result without violation.
 //
-// example: func(runCommand *instructions.RunCommand) RuleValidationResult where runCommand is
-// asserted param as *instructions.RunCommand.
-func (rule *Rule) Validate(param interface{}) RuleValidationResult {
-	// Assemble validationFunc reflect type, based on param type, as they are always
-	// func(param *paramActualType) RuleValidationResult
-	paramType := reflect.TypeOf(param)
-	returnType := reflect.TypeOf(RuleValidationResult{})
-	funcType := reflect.FuncOf([]reflect.Type{paramType}, []reflect.Type{returnType}, false)
-	funcReflect := reflect.ValueOf(rule.validationFunc).Convert(funcType)
-	log.Trace("RuleSet | ValidationReflect> funcType:", funcType)
-
-	// Type assert param into the actual type
-	paramCasted := reflect.ValueOf(param).Convert(paramType)
-
-	// Call the reflection function representation
-	funcReflectResult := funcReflect.Call([]reflect.Value{paramCasted})
-
-	// Get back actual result and assign rule to rule validation result
-	result, ok := funcReflectResult[0].Interface().(RuleValidationResult)
-	if ok {
-		// deep copy
-		r := *rule
-		result.rule = &r
-	} else {
-		log.Error("Cannot retrieve RuleValidationResult from reflect call.")
+// example: a RUN004 rule validates *instructions.RunCommand nodes by calling ValidateRun004.
+func (rule *Rule) Validate(node interface{}, ctx *Context) RuleValidationResult {
+	if rule.validator == nil || NodeKindOf(node) != rule.validator.NodeKind() {
+		log.Error("RuleSet | ", rule.id, " cannot validate ", NodeKindOf(node))
+
+		return RuleValidationResult{isViolated: false, rule: rule.copy()} // nolint:exhaustivestruct
 	}
 
+	result := rule.validator.Validate(node, ctx.withOptions(rule.options))
+	result.rule = rule.copy()
+
 	return result
 }
 
+// copy returns a deep copy of the rule, so its results are not affected by later changes, e.g. of its severity.
+func (rule *Rule) copy() *Rule {
+	r := *rule
+
+	return &r
+}
+
 // NewRule creates a new Rule by joining it's id, definition, description, severity and validation function.
 // It automatically gets assigned into a slice/set of rules corresponding to a specific
-// Dockerfile AST element, inside the ruleMap's corresponding bin, based on the Dockerfile AST
-// element's type. See below, how reflect.TypeOf().String() is used to achieve this.
-func NewRule(id string, definition string, description string, severity Severity, param interface{}) *Rule {
+// Dockerfile AST node kind, inside the ruleMap's corresponding bin. The validation function is either a Validator or
+// a typed function, as described at ValidatorOf. Any other value is a programming error, so it panics.
+func NewRule(id string, definition string, description string, severity Severity, validationFunc interface{}) *Rule {
+	validator, ok := ValidatorOf(validationFunc)
+	if !ok {
+		panic(fmt.Sprintf("RuleSet | %s has an unsupported validation function %T", id, validationFunc))
+	}
+
 	rule := Rule{
-		id:             id,
-		definition:     definition,
-		description:    description,
-		severity:       severity,
-		validationFunc: param,
+		id:          id,
+		definition:  definition,
+		description: description,
+		severity:    severity,
+		options:     nil,
+		validator:   validator,
 	}
 
-	targetBin := reflect.TypeOf(param).In(0).String()
+	targetBin := string(validator.NodeKind())
 
-	// Tests are running in parallel and as such they can potentially cause a race condition
-	ruleMapWriteLock.RLock()
-	if val, ok := ruleMap[targetBin]; ok {
-		ruleMap[targetBin] = append(val, rule)
-	} else {
-		ruleMap[targetBin] = []Rule{rule}
-	}
-	ruleMapWriteLock.RUnlock()
+	// rules are registered by the tests too, while others lint in parallel
+	ruleMapLock.Lock()
+	ruleMap[targetBin] = append(ruleMap[targetBin], rule)
+	ruleMapLock.Unlock()
 
 	return &rule
 }
@@ -172,6 +210,21 @@ func (rule *Rule) Severity() Severity {
 	return rule.severity
 }
 
+// SetSeverity overrides the rule's severity, e.g. based on the config file.
+func (rule *Rule) SetSeverity(severity Severity) {
+	rule.severity = severity
+}
+
+// Options returns the rule's options.
+func (rule *Rule) Options() RuleOptions {
+	return rule.options
+}
+
+// SetOptions sets the rule's options, that are passed to its validation function.
+func (rule *Rule) SetOptions(options RuleOptions) {
+	rule.options = options
+}
+
 // Description returns the rule's description, the idea behind the definition.
 func (rule *Rule) Description() string {
 	return rule.description
@@ -182,8 +235,18 @@ func (rule *Rule) Definition() string {
 	return rule.definition
 }
 
-func (rule *Rule) ValidationFunc() interface{} {
-	return rule.validationFunc
+// Validator returns the rule's validator.
+func (rule *Rule) Validator() Validator {
+	return rule.validator
+}
+
+// AstElementBin returns the kind of the Dockerfile AST nodes that the rule validates, i.e. its ruleMap bin.
+func (rule *Rule) AstElementBin() string {
+	if rule.validator == nil {
+		return ""
+	}
+
+	return string(rule.validator.NodeKind())
 }
 
 // MarshalJSON converts a Rule instance to JSON.
@@ -222,13 +285,16 @@ func (rule *Rule) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// RuleMapType represents a set of rules for each Dockerfile AST element
-// identified by its type's string value (through reflect).
+// RuleMapType represents a set of rules for each Dockerfile AST node kind.
 type RuleMapType map[string][]Rule
 
 // ruleMap stores a ruleset for each Dockerfile AST element that they need to be validated against.
-// It's a map[reflect.TypeOf(Dockerfile AST element).String()][]Rule under the hood.
-var ruleMap RuleMapType = map[string][]Rule{} // nolint:gochecknoglobals
+// It's a map[NodeKindOf(Dockerfile AST element)][]Rule under the hood. It's only accessed under ruleMapLock, and only
+// copies of it and of its rules are handed out, so the registered rules cannot be changed by the linting.
+var (
+	ruleMap     RuleMapType = map[string][]Rule{} // nolint:gochecknoglobals
+	ruleMapLock             = sync.RWMutex{}      // nolint:gochecknoglobals
+)
 
 // Count gives back the total number of rules in the ruleset.
 // Note: each AST element has a set of corresponding rules in the rule map.
@@ -241,15 +307,40 @@ func (ruleMap RuleMapType) Count() int {
 	return sum
 }
 
-// Get returns ruleset's ruleMap.
+// SortedRuleList returns every rule of the ruleset in a single slice, sorted by ID.
+func (ruleMap RuleMapType) SortedRuleList() []Rule {
+	ruleList := make([]Rule, 0, ruleMap.Count())
+	for _, astElementRuleList := range ruleMap {
+		ruleList = append(ruleList, astElementRuleList...)
+	}
+
+	sort.Slice(ruleList, func(i, j int) bool {
+		return ruleList[i].id < ruleList[j].id
+	})
+
+	return ruleList
+}
+
+// Get returns a copy of ruleset's ruleMap.
 func Get() RuleMapType {
-	return ruleMap
+	ruleMapLock.RLock()
+	defer ruleMapLock.RUnlock()
+
+	result := make(RuleMapType, len(ruleMap))
+	for nodeKind, ruleList := range ruleMap {
+		result[nodeKind] = append([]Rule(nil), ruleList...)
+	}
+
+	return result
 }
 
 // GetRulesForAstElement returns a Rule slice with all the rules that
 // the given Dockerfile AST element needs to be validated against.
 func GetRulesForAstElement(astElementInterface interface{}) []Rule {
-	return ruleMap[reflect.TypeOf(astElementInterface).String()]
+	ruleMapLock.RLock()
+	defer ruleMapLock.RUnlock()
+
+	return append([]Rule(nil), ruleMap[string(NodeKindOf(astElementInterface))]...)
 }
 
 // GetRuleByName searches for the rule by its ExampleName in the main rule map.
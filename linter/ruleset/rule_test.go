@@ -5,6 +5,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/stretchr/testify/assert"
 
 	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
@@ -56,32 +57,241 @@ func TestSeverity_MarshalJSON(t *testing.T) {
 	assert.NotEqual(t, nil, err)
 }
 
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Str      string
+		Expected RuleSet.Severity
+		IsValid  bool
+	}{
+		{Name: "Capitalized", Str: "Error", Expected: RuleSet.ValError, IsValid: true},
+		{Name: "Lowercase", Str: "warning", Expected: RuleSet.ValWarning, IsValid: true},
+		{Name: "Uppercase", Str: "DEPRECATION", Expected: RuleSet.ValDeprecation, IsValid: true},
+		{Name: "Invalid", Str: "Fatal", Expected: RuleSet.ValUnknown, IsValid: false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			severity, err := RuleSet.ParseSeverity(testCase.Str)
+
+			assert.Equal(t, testCase.Expected, severity)
+			assert.Equal(t, testCase.IsValid, err == nil)
+		})
+	}
+}
+
+func TestSeverity_IsAtLeast(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gofmt,gofumpt,goimports
+	testCases := []struct {
+		Severity  RuleSet.Severity
+		Threshold RuleSet.Severity
+		Expected  bool
+	}{
+		{Severity: RuleSet.ValError, Threshold: RuleSet.ValError, Expected: true},
+		{Severity: RuleSet.ValError, Threshold: RuleSet.ValInfo, Expected: true},
+		{Severity: RuleSet.ValWarning, Threshold: RuleSet.ValError, Expected: false},
+		{Severity: RuleSet.ValInfo, Threshold: RuleSet.ValWarning, Expected: false},
+		{Severity: RuleSet.ValDeprecation, Threshold: RuleSet.ValInfo, Expected: false},
+		{Severity: RuleSet.ValDeprecation, Threshold: RuleSet.ValDeprecation, Expected: true},
+		{Severity: RuleSet.ValUnknown, Threshold: RuleSet.ValDeprecation, Expected: false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Severity.String()+" >= "+testCase.Threshold.String(), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.Expected, testCase.Severity.IsAtLeast(testCase.Threshold))
+		})
+	}
+}
+
+// newMockValidator returns a validator of the nodes of nodeKind, that are never violated.
+func newMockValidator(nodeKind RuleSet.NodeKind) RuleSet.Validator {
+	return RuleSet.NewValidator(nodeKind, func(interface{}, *RuleSet.Context) RuleSet.RuleValidationResult {
+		return RuleSet.RuleValidationResult{}
+	})
+}
+
 func TestRule_Validate(t *testing.T) {
 	t.Parallel()
 
-	type MockRule struct {
+	type MockNode struct {
 		called int
 	}
 
-	mockFunc := func(rule *MockRule) RuleSet.RuleValidationResult {
-		rule.called++
-		return RuleSet.RuleValidationResult{} // nolint: nlreturn
-	}
+	mockValidator := RuleSet.NewValidator(RuleSet.NodeKindOf(&MockNode{}),
+		func(node interface{}, _ *RuleSet.Context) RuleSet.RuleValidationResult {
+			node.(*MockNode).called++             // nolint:forcetypeassert
+			return RuleSet.RuleValidationResult{} // nolint: nlreturn
+		})
 
-	a := &MockRule{called: 0}
-	rule := RuleSet.NewRule("MockID", "Mock", "MockDesc", RuleSet.ValInfo, mockFunc)
+	a := &MockNode{called: 0}
+	rule := RuleSet.NewRule("MockID", "Mock", "MockDesc", RuleSet.ValInfo, mockValidator)
+
+	rule.Validate(a, nil)
+	assert.Equal(t, 1, a.called)
 
-	rule.Validate(a)
+	// nodes of other kinds are not validated
+	result := rule.Validate(MockNode{called: 0}, nil)
 	assert.Equal(t, 1, a.called)
+	assert.False(t, result.IsViolated())
+	assert.Equal(t, "MockID", result.RuleID())
 }
 
-func TestRule_ValidationFunc(t *testing.T) {
+func TestRule_ValidateWithOptions(t *testing.T) {
 	t.Parallel()
 
-	mockFunc := func(int) {}
-	mockRule := RuleSet.NewRule("MockID", "Mock", "MockDesc", RuleSet.ValInfo, mockFunc)
+	type MockNode struct {
+		options RuleSet.RuleOptions
+	}
+
+	mockValidator := RuleSet.NewValidator(RuleSet.NodeKindOf(&MockNode{}),
+		func(node interface{}, ctx *RuleSet.Context) RuleSet.RuleValidationResult {
+			node.(*MockNode).options = ctx.Options() // nolint:forcetypeassert
+			return RuleSet.RuleValidationResult{}    // nolint: nlreturn
+		})
+
+	a := &MockNode{options: nil}
+	options := RuleSet.RuleOptions{"mockOption": []interface{}{"mockValue", 42}}
+	rule := RuleSet.NewRule("MockID", "Mock", "MockDesc", RuleSet.ValInfo, mockValidator)
+	rule.SetOptions(options)
+	rule.SetSeverity(RuleSet.ValError)
+
+	result := rule.Validate(a, nil)
 
-	assert.ObjectsAreEqual(mockFunc, mockRule.ValidationFunc())
+	assert.Equal(t, options, a.options)
+	assert.Equal(t, []string{"mockValue"}, a.options.StringSlice("mockOption"))
+	assert.Equal(t, []string{}, a.options.StringSlice("missingOption"))
+	assert.Equal(t, RuleSet.ValError, result.Severity())
+}
+
+func TestRule_Validator(t *testing.T) {
+	t.Parallel()
+
+	mockValidator := newMockValidator("int")
+	mockRule := RuleSet.NewRule("MockID", "Mock", "MockDesc", RuleSet.ValInfo, mockValidator)
+
+	assert.Equal(t, mockValidator.NodeKind(), mockRule.Validator().NodeKind())
+}
+
+func TestNewRule_UnsupportedValidationFunc(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { RuleSet.NewRule("MockID", "Mock", "MockDesc", RuleSet.ValInfo, func(int) {}) })
+}
+
+func TestValidatorOf(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name             string
+		ValidationFunc   interface{}
+		IsValid          bool
+		ExpectedNodeKind RuleSet.NodeKind
+	}{
+		{
+			Name:             "Instruction validation function.",
+			ValidationFunc:   RuleSet.ValidateRun004,
+			IsValid:          true,
+			ExpectedNodeKind: "*instructions.RunCommand",
+		},
+		{
+			Name:             "Instruction validation function with options.",
+			ValidationFunc:   RuleSet.ValidateRun001,
+			IsValid:          true,
+			ExpectedNodeKind: "*instructions.RunCommand",
+		},
+		{
+			Name:             "Stage validation function.",
+			ValidationFunc:   RuleSet.ValidateSts001,
+			IsValid:          true,
+			ExpectedNodeKind: "instructions.Stage",
+		},
+		{
+			Name:             "Stage list validation function.",
+			ValidationFunc:   RuleSet.ValidateStl001,
+			IsValid:          true,
+			ExpectedNodeKind: "[]instructions.Stage",
+		},
+		{
+			Name:             "Suppression validation function.",
+			ValidationFunc:   RuleSet.ValidateIgn001,
+			IsValid:          true,
+			ExpectedNodeKind: "*parser.Suppression",
+		},
+		{
+			Name:             "Validator.",
+			ValidationFunc:   newMockValidator("int"),
+			IsValid:          true,
+			ExpectedNodeKind: "int",
+		},
+		{
+			Name:             "Unsupported node kind.",
+			ValidationFunc:   func(int) RuleSet.RuleValidationResult { return RuleSet.RuleValidationResult{} },
+			IsValid:          false,
+			ExpectedNodeKind: "",
+		},
+		{
+			Name:             "Invalid instruction validation function.",
+			ValidationFunc:   RuleSet.ValidateIns001,
+			IsValid:          true,
+			ExpectedNodeKind: "*parser.InvalidInstruction",
+		},
+		{
+			Name:             "Missing result.",
+			ValidationFunc:   func(*instructions.RunCommand) {},
+			IsValid:          false,
+			ExpectedNodeKind: "",
+		},
+		{
+			Name: "Second parameter is not the context.",
+			ValidationFunc: func(*instructions.RunCommand, int) RuleSet.RuleValidationResult {
+				return RuleSet.RuleValidationResult{}
+			},
+			IsValid:          false,
+			ExpectedNodeKind: "",
+		},
+		{
+			Name: "Too many parameters.",
+			ValidationFunc: func(*instructions.RunCommand, *RuleSet.Context, int) RuleSet.RuleValidationResult {
+				return RuleSet.RuleValidationResult{}
+			},
+			IsValid:          false,
+			ExpectedNodeKind: "",
+		},
+		{
+			Name:             "Not a function.",
+			ValidationFunc:   42,
+			IsValid:          false,
+			ExpectedNodeKind: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			validator, ok := RuleSet.ValidatorOf(testCase.ValidationFunc)
+
+			assert.Equal(t, testCase.IsValid, ok)
+
+			if ok {
+				assert.Equal(t, testCase.ExpectedNodeKind, validator.NodeKind())
+			}
+		})
+	}
 }
 
 func TestRuleMapType_Count(t *testing.T) {
@@ -95,11 +305,39 @@ func TestRuleMapType_Count(t *testing.T) {
 	assert.Equal(t, len(mockRuleSet), ruleMap.Count())
 }
 
+func TestRuleMapType_SortedRuleList(t *testing.T) {
+	t.Parallel()
+
+	mockFunc := newMockValidator("int")
+	ruleMap := RuleSet.RuleMapType{}
+	ruleMap["int"] = []RuleSet.Rule{
+		*RuleSet.NewRule("FakeID3", "", "", RuleSet.ValInfo, mockFunc),
+		*RuleSet.NewRule("FakeID1", "", "", RuleSet.ValInfo, mockFunc),
+	}
+	ruleMap["float32"] = []RuleSet.Rule{*RuleSet.NewRule("FakeID2", "", "", RuleSet.ValInfo, mockFunc)}
+
+	ruleList := ruleMap.SortedRuleList()
+
+	assert.Equal(t, 3, len(ruleList))
+	assert.Equal(t, "FakeID1", ruleList[0].ID())
+	assert.Equal(t, "FakeID2", ruleList[1].ID())
+	assert.Equal(t, "FakeID3", ruleList[2].ID())
+}
+
+func TestRule_AstElementBin(t *testing.T) {
+	t.Parallel()
+
+	rule := RuleSet.Get().GetRuleByName("RUN006", nil)
+
+	assert.Equal(t, "*instructions.RunCommand", rule.AstElementBin())
+	assert.Equal(t, "", (&RuleSet.Rule{}).AstElementBin())
+}
+
 func TestRuleMapType_GetRuleByName(t *testing.T) {
 	t.Parallel()
 
 	ruleMap := RuleSet.RuleMapType{}
-	mockFunc := func(int) /* RuleSet.RuleValidationResult */ {}
+	mockFunc := newMockValidator("int")
 	targetName := "FakeID2"
 	mockRule1 := RuleSet.NewRule("FakeID1", "Fake definition 1", "MockDesc 1", RuleSet.ValInfo, mockFunc)
 	mockRule2 := RuleSet.NewRule(targetName, "Fake definition 2", "MockDesc 2", RuleSet.ValUnknown, mockFunc)
@@ -116,7 +354,7 @@ func TestRuleMapType_GetRuleByName(t *testing.T) {
 func TestRule_DocsReference(t *testing.T) {
 	t.Parallel()
 
-	mockFunc := func(int) {}
+	mockFunc := newMockValidator("int")
 	ruleCopy := RuleSet.NewRule("CPY000", "", "", RuleSet.ValUnknown, mockFunc)
 	ruleNone := RuleSet.NewRule("XXX000", "", "", RuleSet.ValUnknown, mockFunc)
 
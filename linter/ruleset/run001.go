@@ -10,8 +10,11 @@ import (
 var _ = NewRule("RUN001", "Some bash commands make no sense in an ordinary Docker container.", "", ValWarning,
 	ValidateRun001)
 
-func ValidateRun001(runCommand *instructions.RunCommand) RuleValidationResult {
+// ValidateRun001 checks for bash commands that make no sense in a container.
+// The list of these commands can be extended through the "additionalCommands" option.
+func ValidateRun001(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	invalidCmdSet := []string{"free", "kill", "mount", "ps", "reboot", "service", "shutdown", "top"}
+	invalidCmdSet = append(invalidCmdSet, ctx.Options().StringSlice("additionalCommands")...)
 
 	result := RuleValidationResult{
 		isViolated:    false,
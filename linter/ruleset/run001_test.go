@@ -0,0 +1,55 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateRun001(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gofmt,gofumpt,goimports
+	testCases := []struct {
+		CommandStr  string
+		Options     RuleSet.RuleOptions
+		IsViolation bool
+		ExampleName string
+	}{
+		{CommandStr: "ps aux",          Options: nil, IsViolation: true,  ExampleName: "ps"},
+		{CommandStr: "date && top",     Options: nil, IsViolation: true,  ExampleName: "top after date"},
+		{CommandStr: "apt-get update",  Options: nil, IsViolation: false, ExampleName: "apt-get update"},
+		{
+			CommandStr:  "htop",
+			Options:     RuleSet.RuleOptions{"additionalCommands": []interface{}{"htop"}},
+			IsViolation: true,
+			ExampleName: "htop with additionalCommands option",
+		},
+		{
+			CommandStr:  "htop",
+			Options:     nil,
+			IsViolation: false,
+			ExampleName: "htop without additionalCommands option",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			commandBody := instructions.ShellDependantCmdLine{CmdLine: []string{testCase.CommandStr}, PrependShell: true}
+			runCommand := &instructions.RunCommand{ShellDependantCmdLine: commandBody}
+
+			// the options are passed through the context by the rule
+			rule := RuleSet.Get().GetRuleByName("RUN001", nil)
+			rule.SetOptions(testCase.Options)
+
+			assert.Equal(t, testCase.IsViolation, rule.Validate(runCommand, nil).IsViolated())
+		})
+	}
+}
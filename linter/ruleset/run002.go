@@ -15,7 +15,7 @@ import (
 var _ = NewRule("RUN002", "Consider pinning versions of packages", "", ValWarning, ValidateRun002)
 
 // nolint:funlen
-func ValidateRun002(runCommand *instructions.RunCommand) RuleValidationResult {
+func ValidateRun002(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(runCommand),
@@ -84,8 +84,8 @@ func ValidateRun002(runCommand *instructions.RunCommand) RuleValidationResult {
 	result.SetViolated(len(packageWithoutVersionList) > 0)
 
 	// Update location
-	if result.isViolated && Parser.RawParser.IsInitialized() {
-		packageLocationRangeSlice := ParseLocationSliceFromRawParser(packageWithoutVersionList, runCommand.Location())
+	if result.isViolated && ctx.HasSource() {
+		packageLocationRangeSlice := ctx.ParseLocationSlice(packageWithoutVersionList, runCommand.Location())
 		result.LocationRange = UnionOfLocationRanges(packageLocationRangeSlice)
 	}
 
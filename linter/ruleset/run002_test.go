@@ -83,7 +83,7 @@ func TestValidateRun002(t *testing.T) {
 			runCommandWithoutSudo := &instructions.RunCommand{ShellDependantCmdLine: commandBody}
 
 			// test validation rule
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateRun002(runCommandWithoutSudo).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateRun002(runCommandWithoutSudo, nil).IsViolated())
 		})
 	}
 }
@@ -9,7 +9,7 @@ import (
 var _ = NewRule("RUN003", "Operators \"&&, ||, |\" has no affect after semicolon.", "", ValError,
 	ValidateRun003)
 
-func ValidateRun003(runCommand *instructions.RunCommand) RuleValidationResult {
+func ValidateRun003(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(runCommand),
@@ -19,7 +19,7 @@ func ValidateRun003(runCommand *instructions.RunCommand) RuleValidationResult {
 	// TODO: consider using FindSubmatchIndex in order to support multiple locations
 	if match := regexpInvalidPattern.FindString(runCommand.String()); len(match) > 0 {
 		result.SetViolated()
-		result.LocationRange = ParseLocationFromRawParser(match, runCommand.Location())
+		result.LocationRange = ctx.ParseLocation(match, runCommand.Location())
 		result.message = "Probably not what you wanted: " + match
 	}
 
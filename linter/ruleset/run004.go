@@ -12,7 +12,7 @@ var _ = NewRule(
 	ValWarning,
 	ValidateRun004)
 
-func ValidateRun004(runCommand *instructions.RunCommand) RuleValidationResult {
+func ValidateRun004(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(runCommand),
@@ -22,7 +22,7 @@ func ValidateRun004(runCommand *instructions.RunCommand) RuleValidationResult {
 	for _, bashCommand := range bashCommandList {
 		if bashCommand.HasSudo() {
 			result.SetViolated()
-			result.LocationRange = ParseLocationFromRawParser("sudo", runCommand.Location())
+			result.LocationRange = ctx.ParseLocation("sudo", runCommand.Location())
 		}
 	}
 
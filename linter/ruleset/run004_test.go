@@ -57,7 +57,7 @@ func TestValidateRun004(t *testing.T) {
 
 			commandBody := instructions.ShellDependantCmdLine{CmdLine: []string{testCase.command}, PrependShell: true}
 			runCommandWithoutSudo := &instructions.RunCommand{ShellDependantCmdLine: commandBody}
-			result := RuleSet.ValidateRun004(runCommandWithoutSudo).IsViolated()
+			result := RuleSet.ValidateRun004(runCommandWithoutSudo, nil).IsViolated()
 			assert.Equal(t, result, testCase.violation)
 		})
 	}
@@ -8,7 +8,7 @@ import (
 
 var _ = NewRule("RUN005", "Do not upgrade or dist-upgrade the base image", "", ValError, ValidateRun005)
 
-func ValidateRun005(runCommand *instructions.RunCommand) RuleValidationResult {
+func ValidateRun005(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	notAdvisedPackageManagerCommandMap := map[string][]string{
 		"apt":     {"upgrade", "dist-upgrade"},
 		"apt-get": {"upgrade", "dist-upgrade"},
@@ -29,7 +29,7 @@ func ValidateRun005(runCommand *instructions.RunCommand) RuleValidationResult {
 			for _, notAdvisedCommand := range notAdvisedCommandSlice {
 				if bashCommand.Bin() == packageManager && bashCommand.SubCommand() == notAdvisedCommand {
 					result.SetViolated()
-					result.LocationRange = ParseLocationFromRawParser(bashCommand.SubCommand(), runCommand.Location())
+					result.LocationRange = ctx.ParseLocation(bashCommand.SubCommand(), runCommand.Location())
 				}
 			}
 		}
@@ -13,7 +13,7 @@ import (
 var _ = NewRule("RUN006", "Clean cache after package manager operation.", "", ValWarning,
 	ValidateRun006)
 
-func ValidateRun006(runCommand *instructions.RunCommand) RuleValidationResult {
+func ValidateRun006(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(runCommand),
@@ -53,7 +53,7 @@ func ValidateRun006(runCommand *instructions.RunCommand) RuleValidationResult {
 
 				return RuleValidationResult{
 					isViolated:    true,
-					LocationRange: ParseLocationFromRawParser(packageManager, runCommand.Location()),
+					LocationRange: ctx.ParseLocation(packageManager, runCommand.Location()),
 				}
 			}
 		}
@@ -8,7 +8,7 @@ import (
 
 var _ = NewRule("RUN007", "Use 'WORKDIR' to switch to a directory.", "", ValWarning, ValidateRun007)
 
-func ValidateRun007(runCommand *instructions.RunCommand) RuleValidationResult {
+func ValidateRun007(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(runCommand),
@@ -21,12 +21,12 @@ func ValidateRun007(runCommand *instructions.RunCommand) RuleValidationResult {
 	// RUN command starts with "cd" right away
 	if bashCommandList[0].Bin() == "cd" {
 		result.SetViolated()
-		result.LocationRange = ParseLocationFromRawParser(bashCommandList[0].Bin(), runCommand.Location())
+		result.LocationRange = ctx.ParseLocation(bashCommandList[0].Bin(), runCommand.Location())
 	} else if len(bashCommandList) >= 2 { // nolint:gomnd
 		// RUN command starts with mkdir and then followed by a cd
 		if bashCommandList[0].Bin() == "mkdir" && bashCommandList[1].Bin() == "cd" {
 			result.SetViolated()
-			result.LocationRange = ParseLocationFromRawParser(bashCommandList[0].Bin(), runCommand.Location())
+			result.LocationRange = ctx.ParseLocation(bashCommandList[0].Bin(), runCommand.Location())
 		}
 	}
 
@@ -1,6 +1,8 @@
 package ruleset
 
 import (
+	"regexp"
+
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 
@@ -11,8 +13,30 @@ import (
 var _ = NewRule("RUN009", "Pass assume yes flag to package manager in order to be headless.", "",
 	ValWarning, ValidateRun009)
 
+var _ = RegisterFix("RUN009", "Pass -y to the package manager.", InstructionFix(FixRun009))
+
+// nolint:gochecknoglobals
+var (
+	regexpRun009PackageManagerCommand = regexp.MustCompile(
+		`\b(apt-get|apt|dnf|yum|zypper)\s+(install|remove|purge|downgrade|in|rm)\b`)
+	run009AssumeYesOptionMap = map[string][]string{
+		"apt":     {"-y", "--yes", "--assume-yes"},
+		"apt-get": {"-y", "--yes", "--assume-yes"},
+		"dnf":     {"-y", "--assumeyes"},
+		"yum":     {"-y", "--assumeyes"},
+		"zypper":  {"-y", "--no-confirm", "-n", "--non-interactive"},
+	}
+)
+
+// FixRun009 passes -y to the package manager commands, that would prompt for confirmation.
+func FixRun009(instruction string) (string, bool) {
+	return insertOption(instruction, regexpRun009PackageManagerCommand, "-y", func(bin string) []string {
+		return run009AssumeYesOptionMap[bin]
+	}), true
+}
+
 // nolint: funlen, nestif
-func ValidateRun009(runCommand *instructions.RunCommand) RuleValidationResult {
+func ValidateRun009(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(runCommand),
@@ -65,9 +89,9 @@ func ValidateRun009(runCommand *instructions.RunCommand) RuleValidationResult {
 				}
 
 				if len(adjustedLocation) == 0 {
-					result.LocationRange = ParseLocationFromRawParser(bashCommand.Bin(), runCommand.Location())
+					result.LocationRange = ctx.ParseLocation(bashCommand.Bin(), runCommand.Location())
 				} else {
-					result.LocationRange = ParseLocationFromRawParser(bashCommand.Bin(), adjustedLocation)
+					result.LocationRange = ctx.ParseLocation(bashCommand.Bin(), adjustedLocation)
 				}
 			}
 		}
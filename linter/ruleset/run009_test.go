@@ -40,7 +40,7 @@ func TestValidateRun009(t *testing.T) {
 			runCommandWithoutSudo := &instructions.RunCommand{ShellDependantCmdLine: commandBody}
 
 			// test validation rule
-			assert.Equal(t, testCase.isViolation, RuleSet.ValidateRun009(runCommandWithoutSudo).IsViolated())
+			assert.Equal(t, testCase.isViolation, RuleSet.ValidateRun009(runCommandWithoutSudo, nil).IsViolated())
 		})
 	}
 }
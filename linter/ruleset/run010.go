@@ -1,6 +1,8 @@
 package ruleset
 
 import (
+	"regexp"
+
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 
 	Parser "github.com/cremindes/whalelint/parser"
@@ -10,7 +12,20 @@ import (
 var _ = NewRule("RUN010", "Pass --no-install-recommends to avoid installing unnecessary packages.", "",
 	ValWarning, ValidateRun010)
 
-func ValidateRun010(runCommand *instructions.RunCommand) RuleValidationResult {
+var _ = RegisterFix("RUN010", "Pass --no-install-recommends to apt.", InstructionFix(FixRun010))
+
+var regexpRun010AptInstall = regexp.MustCompile(`\b(apt-get|apt)\s+install\b`) // nolint:gochecknoglobals
+
+// FixRun010 passes --no-install-recommends to the apt install commands.
+func FixRun010(instruction string) (string, bool) {
+	option := "--no-install-recommends"
+
+	return insertOption(instruction, regexpRun010AptInstall, option, func(string) []string {
+		return []string{option}
+	}), true
+}
+
+func ValidateRun010(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(runCommand),
@@ -24,7 +39,7 @@ func ValidateRun010(runCommand *instructions.RunCommand) RuleValidationResult {
 		if Utils.EqualsEither(bashCommand.Bin(), binSlice) && bashCommand.SubCommand() == "install" &&
 			!Utils.SliceContains(bashCommand.OptionKeyList(), option) {
 			result.SetViolated()
-			result.LocationRange = ParseLocationFromRawParser(bashCommand.SubCommand(), runCommand.Location())
+			result.LocationRange = ctx.ParseLocation(bashCommand.SubCommand(), runCommand.Location())
 		}
 	}
 
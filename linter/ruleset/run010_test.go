@@ -42,7 +42,7 @@ func TestValidateRun010(t *testing.T) {
 			runCommandWithoutSudo := &instructions.RunCommand{ShellDependantCmdLine: commandBody}
 
 			// test validation rule
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateRun010(runCommandWithoutSudo).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateRun010(runCommandWithoutSudo, nil).IsViolated())
 		})
 	}
 }
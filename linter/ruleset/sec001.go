@@ -0,0 +1,59 @@
+package ruleset
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Parser "github.com/cremindes/whalelint/parser"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+var _ = NewRule("SEC001", "RUN should not contain a secret, e.g. a token or a password.", "The command line of a "+
+	"RUN instruction is persisted in the image history, so anyone with access to the image can read the secrets in "+
+	"it. Mount them with `RUN --mount=type=secret,id=npm_token` and read them from `/run/secrets/npm_token` instead.",
+	ValError, ValidateSec001)
+
+func ValidateSec001(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(runCommand),
+	}
+
+	for _, bashCommand := range Parser.ParseBashCommandChain(runCommand).BashCommandList {
+		secret, ok := findSecret(bashCommand.String())
+		if !ok {
+			secret, ok = findAssignedSecretInMap(bashCommandAssignments(&bashCommand))
+		}
+
+		if ok {
+			result.SetViolated()
+			result.message = secretMessage(secret)
+			result.secret = secret.value
+			result.LocationRange = ctx.ParseLocation(secret.value, runCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
+
+// bashCommandAssignments returns the variables and options, that the bash command assigns a value to, e.g.
+// NPM_TOKEN=abc npm publish --token=abc.
+func bashCommandAssignments(bashCommand *Parser.BashCommand) map[string]string {
+	assignmentMap := make(map[string]string)
+
+	for _, kv := range []map[string]string{bashCommand.EnvVars(), bashCommand.ArgMap()} {
+		for key, value := range kv {
+			assignmentMap[key] = value
+		}
+	}
+
+	for option := range bashCommand.OptionList() {
+		key, value := Utils.SplitKeyValue(strings.TrimLeft(option, "-"), '=')
+		assignmentMap[key] = value
+	}
+
+	return assignmentMap
+}
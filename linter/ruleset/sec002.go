@@ -0,0 +1,30 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("SEC002", "ENV should not contain a secret, e.g. a token or a password.", "ENV variables are "+
+	"persisted in the image and its history, so anyone with access to the image can read them. Mount the secret "+
+	"with `RUN --mount=type=secret` in the instructions, that need it, instead.",
+	ValError, ValidateSec002)
+
+func ValidateSec002(envCommand *instructions.EnvCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(envCommand),
+	}
+
+	for _, env := range envCommand.Env {
+		if secret, ok := findAssignedSecret(env.Key, env.Value); ok {
+			result.SetViolated()
+			result.message = secretMessage(secret)
+			result.secret = secret.value
+			result.LocationRange = ctx.ParseLocation(secret.value, envCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,34 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("SEC003", "ARG should not have a secret as its default value.", "The default values of ARGs, "+
+	"like the ones passed by `--build-arg`, are persisted in the image history. Mount the secret with "+
+	"`RUN --mount=type=secret` in the instructions, that need it, instead.",
+	ValError, ValidateSec003)
+
+func ValidateSec003(argCommand *instructions.ArgCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(argCommand),
+	}
+
+	for _, arg := range argCommand.Args {
+		if arg.Value == nil {
+			continue
+		}
+
+		if secret, ok := findAssignedSecret(arg.Key, *arg.Value); ok {
+			result.SetViolated()
+			result.message = secretMessage(secret)
+			result.secret = secret.value
+			result.LocationRange = ctx.ParseLocation(secret.value, argCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,29 @@
+package ruleset
+
+import (
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("SEC004", "LABEL should not contain a secret, e.g. a token or a password.", "Labels are part of "+
+	"the image metadata, so anyone with access to the image can read them.",
+	ValError, ValidateSec004)
+
+func ValidateSec004(labelCommand *instructions.LabelCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(labelCommand),
+	}
+
+	for _, label := range labelCommand.Labels {
+		if secret, ok := findAssignedSecret(label.Key, label.Value); ok {
+			result.SetViolated()
+			result.message = secretMessage(secret)
+			result.secret = secret.value
+			result.LocationRange = ctx.ParseLocation(secret.value, labelCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package ruleset
+
+import (
+	"path"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+var _ = NewRule("SEC005", "COPY should not copy credentials, e.g. SSH keys or .npmrc, into the image.", "Files "+
+	"copied into an image stay in its layer, even if a later instruction deletes them. Mount them with "+
+	"`RUN --mount=type=secret` or `RUN --mount=type=ssh` in the instructions, that need them, instead.",
+	ValError, ValidateSec005)
+
+// credentialFileList are the files and directories, that usually hold credentials.
+var credentialFileList = []string{ // nolint:gochecknoglobals
+	".aws", ".dockercfg", ".git-credentials", ".gnupg", ".kube", ".netrc", ".npmrc", ".pypirc", ".ssh",
+	"id_dsa", "id_ecdsa", "id_ed25519", "id_rsa",
+}
+
+func ValidateSec005(copyCommand *instructions.CopyCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(copyCommand),
+	}
+
+	for _, sourcePath := range copyCommand.SourcesAndDest.SourcePaths {
+		if credentialFile, ok := credentialFileOf(sourcePath); ok {
+			result.SetViolated()
+			result.message = "Do not copy " + credentialFile + " into the image."
+			result.LocationRange = ctx.ParseLocation(sourcePath, copyCommand.Location())
+
+			break
+		}
+	}
+
+	return result
+}
+
+// credentialFileOf returns the credential file or directory in the path, if there is any, e.g. .ssh in .ssh/config.
+// Private keys are matched by prefix, e.g. id_rsa_deploy, but the public ones, e.g. id_rsa.pub, are fine.
+func credentialFileOf(filePath string) (string, bool) {
+	for _, element := range strings.Split(path.Clean(filePath), "/") {
+		for _, credentialFile := range credentialFileList {
+			if element == credentialFile || strings.HasPrefix(credentialFile, "id_") &&
+				strings.HasPrefix(element, credentialFile) && !strings.HasSuffix(element, ".pub") {
+				return credentialFile, true
+			}
+		}
+	}
+
+	return "", false
+}
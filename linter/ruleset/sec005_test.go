@@ -0,0 +1,67 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateSec005(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "COPY of the sources.",
+			DocsContext: "FROM golang:1.17\nCOPY . /app",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "COPY of an SSH key.",
+			DocsContext: "FROM golang:1.17\nCOPY id_rsa /root/.ssh/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "COPY of the SSH directory.",
+			DocsContext: "FROM golang:1.17\nCOPY .ssh/ /root/.ssh/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "COPY of the AWS credentials.",
+			DocsContext: "FROM golang:1.17\nCOPY .aws/credentials /root/.aws/",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "COPY of .npmrc.",
+			DocsContext: "FROM node:16\nCOPY package.json .npmrc ./",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "COPY of a public SSH key.",
+			DocsContext: "FROM golang:1.17\nCOPY id_rsa.pub /root/.ssh/authorized_keys",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("SEC005", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+			command, ok := stageList[0].Commands[0].(*instructions.CopyCommand)
+			assert.True(t, ok)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSec005(command, ctx).IsViolated())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package ruleset
+
+import (
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// secret is a credential found in a Dockerfile instruction.
+type secret struct {
+	// kind is the kind of the secret, e.g. "GitHub token".
+	kind string
+	// value is the secret as it's written in the Dockerfile.
+	value string
+}
+
+// masked returns the secret with only its first few characters visible, so it can be reported without leaking it.
+func (secret secret) masked() string {
+	return maskSecret(secret.value)
+}
+
+// maskSecret keeps at most the first 4 characters of str, but no more than a quarter of it, and masks the rest.
+func maskSecret(str string) string {
+	visibleLength := len(str) / 4 // nolint:gomnd
+	if visibleLength > 4 {        // nolint:gomnd
+		visibleLength = 4
+	}
+
+	return str[:visibleLength] + "****"
+}
+
+// secretPattern is a known secret format. The first capture group of the regexp is the secret, if there is any,
+// otherwise the whole match.
+type secretPattern struct {
+	kind   string
+	regexp *regexp.Regexp
+}
+
+// secretPatternList are the known secret formats. Values, that start with a variable reference, are not secrets, as
+// they are expected to be passed in, e.g. `Authorization: Bearer $TOKEN`.
+var secretPatternList = []secretPattern{ // nolint:gochecknoglobals
+	{"private key", regexp.MustCompile(`-----BEGIN[A-Z ]* PRIVATE KEY-----`)},
+	{"AWS access key ID", regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"GitLab token", regexp.MustCompile(`\b(glpat-[A-Za-z0-9_-]{20,})`)},
+	{"Slack token", regexp.MustCompile(`\b(xox[abprs]-[A-Za-z0-9-]{10,})`)},
+	{"npm token", regexp.MustCompile(`\b(npm_[A-Za-z0-9]{36})\b`)},
+	{"npm auth token", regexp.MustCompile(`_auth(?:Token)?=([^\s"'$][^\s"']*)`)},
+	{"authorization header", regexp.MustCompile(`(?i)authorization:\s*(?:bearer|basic|token)\s+([^\s"'$][^\s"']*)`)},
+	{"password in URL", regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^/\s:@"']+:([^/\s@"'$][^/\s@"']*)@`)},
+	{"password", regexp.MustCompile(`--password[= ]([^\s"'$-][^\s"']*)`)},
+}
+
+const (
+	minSecretLength  = 8
+	minSecretEntropy = 3.0
+)
+
+// findSecret returns the first secret in str, that matches a known secret format.
+func findSecret(str string) (secret, bool) {
+	for _, pattern := range secretPatternList {
+		matchList := pattern.regexp.FindStringSubmatch(str)
+		if matchList == nil {
+			continue
+		}
+
+		value := matchList[0]
+		if len(matchList) > 1 {
+			value = matchList[1]
+		}
+
+		return secret{kind: pattern.kind, value: value}, true
+	}
+
+	return secret{}, false
+}
+
+// findAssignedSecret returns the secret in the value assigned to name, e.g. in an ENV. Besides the known secret
+// formats, a literal value of a name, that looks like a secret, is one, if it's random enough. Low entropy values
+// are usually placeholders or settings, e.g. PASSWORD_HASH=bcrypt.
+func findAssignedSecret(name string, value string) (secret, bool) {
+	if secret, ok := findSecret(value); ok {
+		return secret, true
+	}
+
+	value = strings.Trim(value, `"'`)
+	normalizedName := strings.NewReplacer(".", "_", "-", "_").Replace(name) // e.g. LABEL api.key or --api-key
+
+	if !looksLikeSecretName(normalizedName) || len(value) < minSecretLength || len(variableReferences(value)) > 0 ||
+		path.IsAbs(value) || shannonEntropy(value) < minSecretEntropy {
+		return secret{}, false
+	}
+
+	return secret{kind: "secret in " + name, value: value}, true
+}
+
+// findAssignedSecretInMap returns the first secret assigned in the map, in the order of the names.
+func findAssignedSecretInMap(kv map[string]string) (secret, bool) {
+	nameList := make([]string, 0, len(kv))
+	for name := range kv {
+		nameList = append(nameList, name)
+	}
+
+	sort.Strings(nameList)
+
+	for _, name := range nameList {
+		if secret, ok := findAssignedSecret(name, kv[name]); ok {
+			return secret, true
+		}
+	}
+
+	return secret{}, false
+}
+
+// shannonEntropy returns the Shannon entropy of str in bits per character.
+func shannonEntropy(str string) float64 {
+	if len(str) == 0 {
+		return 0
+	}
+
+	countMap := make(map[rune]int)
+	for _, r := range str {
+		countMap[r]++
+	}
+
+	entropy := 0.0
+	length := float64(len([]rune(str)))
+
+	for _, count := range countMap {
+		probability := float64(count) / length
+		entropy -= probability * math.Log2(probability)
+	}
+
+	return entropy
+}
+
+// secretMessage returns the violation message of the secret, that shows only its masked value.
+func secretMessage(secret secret) string {
+	return "Found " + secret.kind + ": " + secret.masked() + ". Pass it with `RUN --mount=type=secret` instead."
+}
@@ -0,0 +1,58 @@
+package ruleset
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// SIG -> STOPSIGNAL.
+var _ = NewRule("SIG001", "STOPSIGNAL must be a valid signal name or number.", "The signal is either a name, "+
+	"e.g. SIGTERM or TERM, or a number between 1 and 64. An invalid one fails to start the container.",
+	ValError, ValidateSig001)
+
+// signalNameList are the names of the Linux signals without the SIG prefix.
+var signalNameList = []string{ // nolint:gochecknoglobals
+	"ABRT", "ALRM", "BUS", "CHLD", "CLD", "CONT", "FPE", "HUP", "ILL", "INT", "IO", "IOT", "KILL", "PIPE", "POLL",
+	"PROF", "PWR", "QUIT", "RTMAX", "RTMIN", "SEGV", "STKFLT", "STOP", "SYS", "TERM", "TRAP", "TSTP", "TTIN",
+	"TTOU", "UNUSED", "URG", "USR1", "USR2", "VTALRM", "WINCH", "XCPU", "XFSZ",
+}
+
+// regexpRealTimeSignal matches the real-time signals relative to the range, e.g. RTMIN+1 or RTMAX-2.
+var regexpRealTimeSignal = regexp.MustCompile( // nolint:gochecknoglobals
+	`^RTMIN\+([1-9]|1[0-5])$|^RTMAX-([1-9]|1[0-4])$`)
+
+const maxSignalNumber = 64
+
+func ValidateSig001(stopSignalCommand *instructions.StopSignalCommand, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{
+		isViolated:    false,
+		LocationRange: LocationRangeFromCommand(stopSignalCommand),
+	}
+
+	signal := stopSignalCommand.Signal
+	if len(variableReferences(signal)) > 0 || isValidSignal(signal) {
+		return result
+	}
+
+	result.SetViolated()
+	result.message = signal + " is not a valid signal."
+	result.LocationRange = ctx.ParseLocation(signal, stopSignalCommand.Location())
+
+	return result
+}
+
+// isValidSignal tells, whether the signal is a valid signal number, or name with or without the SIG prefix.
+func isValidSignal(signal string) bool {
+	if number, err := strconv.Atoi(signal); err == nil {
+		return 1 <= number && number <= maxSignalNumber
+	}
+
+	name := strings.TrimPrefix(strings.ToUpper(signal), "SIG")
+
+	return Utils.EqualsEither(name, signalNameList) || regexpRealTimeSignal.MatchString(name)
+}
@@ -0,0 +1,67 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateSig001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "Signal name.",
+			DocsContext: "FROM nginx:1.21\nSTOPSIGNAL SIGQUIT",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Signal name without the SIG prefix.",
+			DocsContext: "FROM nginx:1.21\nSTOPSIGNAL quit",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Signal number.",
+			DocsContext: "FROM nginx:1.21\nSTOPSIGNAL 3",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "Real-time signal.",
+			DocsContext: "FROM nginx:1.21\nSTOPSIGNAL SIGRTMIN+3",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Unknown signal name.",
+			DocsContext: "FROM nginx:1.21\nSTOPSIGNAL SIGSTOPP",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "Signal number out of range.",
+			DocsContext: "FROM nginx:1.21\nSTOPSIGNAL 0",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("SIG001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+			command, ok := stageList[0].Commands[0].(*instructions.StopSignalCommand)
+			assert.True(t, ok)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSig001(command, ctx).IsViolated())
+		})
+	}
+}
@@ -9,14 +9,14 @@ import (
 // STL -> Stage List.
 var _ = NewRule("STL001", "Stage name alias must be unique.", "", ValError, ValidateStl001)
 
-func ValidateStl001(stageList []instructions.Stage) RuleValidationResult {
+func ValidateStl001(stageList []instructions.Stage, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{isViolated: false, LocationRange: LocationRange{}}
 	stageNameSet := set.NewSet()
 
 	for _, stage := range stageList {
 		if stageNameSet.Contains(stage.Name) && stage.Name != "" { // found a non-unique build stage alias
 			result.SetViolated()
-			result.LocationRange = ParseLocationFromRawParser(stage.Name, stage.Location)
+			result.LocationRange = ctx.ParseLocation(stage.Name, stage.Location)
 		}
 
 		err := stageNameSet.Add(stage.Name)
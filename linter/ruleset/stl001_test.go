@@ -70,7 +70,7 @@ func TestValidateStl001(t *testing.T) { // nolint:funlen
 				stageList = append(stageList, instructions.Stage{Name: stageName}) // nolint:exhaustivestruct
 			}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateStl001(stageList).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateStl001(stageList, nil).IsViolated())
 		})
 	}
 }
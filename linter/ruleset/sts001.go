@@ -9,7 +9,7 @@ import (
 // STS -> Stage Single.
 var _ = NewRule("STS001", "Stage name should have an explicit tag..", "", ValWarning, ValidateSts001)
 
-func ValidateSts001(stage instructions.Stage) RuleValidationResult {
+func ValidateSts001(stage instructions.Stage, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
 
 	if stage.BaseName == "scratch" { // special explicitly empty image
@@ -21,7 +21,7 @@ func ValidateSts001(stage instructions.Stage) RuleValidationResult {
 
 	if result.IsViolated() {
 		result.message = "Image \"" + image + "\" should have an explicit tag."
-		result.LocationRange = ParseLocationFromRawParser(stage.BaseName, stage.Location)
+		result.LocationRange = ctx.ParseLocation(stage.BaseName, stage.Location)
 	}
 
 	return result
@@ -54,7 +54,7 @@ func TestValidateSts001(t *testing.T) {
 			// nolint:exhaustivestruct
 			stage := instructions.Stage{BaseName: testCase.StageBaseName, SourceCode: "FROM " + testCase.StageBaseName}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSts001(stage).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSts001(stage, nil).IsViolated())
 		})
 	}
 }
@@ -9,7 +9,7 @@ import (
 // STS -> Stage Single.
 var _ = NewRule("STS002", "Stage name \"latest\" is prone to future errors.", "TODO", ValWarning, ValidateSts002)
 
-func ValidateSts002(stage instructions.Stage) RuleValidationResult {
+func ValidateSts002(stage instructions.Stage, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
 
 	image, tag := utils.SplitKeyValue(stage.BaseName, ':')
@@ -17,7 +17,7 @@ func ValidateSts002(stage instructions.Stage) RuleValidationResult {
 
 	if result.IsViolated() {
 		result.message = "Image \"" + image + "\" should not use \"latest\" as tag."
-		result.LocationRange = ParseLocationFromRawParser(stage.BaseName, stage.Location)
+		result.LocationRange = ctx.ParseLocation(stage.BaseName, stage.Location)
 	}
 
 	return result
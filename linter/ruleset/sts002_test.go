@@ -54,7 +54,7 @@ func TestValidateSts002(t *testing.T) {
 			// nolint:exhaustivestruct
 			stage := instructions.Stage{BaseName: testCase.StageBaseName, SourceCode: "FROM " + testCase.StageBaseName}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSts002(stage).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSts002(stage, nil).IsViolated())
 		})
 	}
 }
@@ -8,14 +8,14 @@ import (
 var _ = NewRule("STS003", "Platform should be specified in build tool and not FROM.", "TODO",
 	ValWarning, ValidateSts003)
 
-func ValidateSts003(stage instructions.Stage) RuleValidationResult {
+func ValidateSts003(stage instructions.Stage, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
 
 	result.SetViolated(len(stage.Platform) > 0)
 
 	if result.IsViolated() {
 		result.message = "Specifying platform at build tool level gives more flexibility."
-		result.LocationRange = ParseLocationFromRawParser(stage.Platform, stage.Location)
+		result.LocationRange = ctx.ParseLocation(stage.Platform, stage.Location)
 	}
 
 	return result
@@ -51,7 +51,7 @@ func TestValidateSts003(t *testing.T) {
 				SourceCode: stageSourceCode,
 			}
 
-			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSts003(stage).IsViolated())
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateSts003(stage, nil).IsViolated())
 		})
 	}
 }
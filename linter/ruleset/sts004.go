@@ -9,7 +9,7 @@ import (
 var _ = NewRule("STS004", "There should only be 1 CMD and/or ENTRYPOINT command.", "TODO",
 	ValWarning, ValidateSts004)
 
-func ValidateSts004(stage instructions.Stage) RuleValidationResult {
+func ValidateSts004(stage instructions.Stage, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
 
 	cmdCommands := filter.Choose(stage.Commands, func(c instructions.Command) bool { return c.Name() == "cmd" })
@@ -25,7 +25,7 @@ func ValidateSts004(stage instructions.Stage) RuleValidationResult {
 			// location
 			if cmdCommand, ok := commandSlice[1].(*instructions.CmdCommand); ok {
 				str := cmdCommand.String()[:len("CMD")] // in case the command is lowercase
-				result.LocationRange = ParseLocationFromRawParser(str, cmdCommand.Location())
+				result.LocationRange = ctx.ParseLocation(str, cmdCommand.Location())
 			}
 		}
 	}
@@ -38,7 +38,7 @@ func ValidateSts004(stage instructions.Stage) RuleValidationResult {
 			// location
 			if entrypointCommand, ok := commandSlice[1].(*instructions.EntrypointCommand); ok {
 				str := entrypointCommand.String()[:len("ENTRYPOINT")] // in case the command is lowercase
-				result.LocationRange = ParseLocationFromRawParser(str, entrypointCommand.Location())
+				result.LocationRange = ctx.ParseLocation(str, entrypointCommand.Location())
 			}
 		}
 	}
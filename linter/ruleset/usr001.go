@@ -6,7 +6,7 @@ import (
 
 var _ = NewRule("USR001", "Last USER should not be root.", "", ValWarning, ValidateUsr001)
 
-func ValidateUsr001(stageList []instructions.Stage) RuleValidationResult {
+func ValidateUsr001(stageList []instructions.Stage, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{isViolated: false, LocationRange: LocationRange{}}
 
 	lastUser := ""
@@ -16,7 +16,7 @@ func ValidateUsr001(stageList []instructions.Stage) RuleValidationResult {
 		for _, command := range stage.Commands {
 			if userCommand, ok := command.(*instructions.UserCommand); ok {
 				lastUser = userCommand.User
-				lastUserLocationRange = ParseLocationFromRawParser(lastUser, userCommand.Location())
+				lastUserLocationRange = ctx.ParseLocation(lastUser, userCommand.Location())
 			}
 		}
 	}
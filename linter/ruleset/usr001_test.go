@@ -45,7 +45,7 @@ func TestValidateUsr001(t *testing.T) {
 				})
 			}
 
-			assert.Equal(t, testCase.isViolation, RuleSet.ValidateUsr001(stageList).IsViolated())
+			assert.Equal(t, testCase.isViolation, RuleSet.ValidateUsr001(stageList, nil).IsViolated())
 		})
 	}
 }
@@ -1,10 +1,17 @@
 package ruleset
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
+
+	Utils "github.com/cremindes/whalelint/utils"
 )
 
 const FORCE = true // used for overriding the latched isViolated flag in SetViolated.
@@ -13,6 +20,9 @@ type RuleValidationResult struct {
 	rule          *Rule
 	isViolated    bool
 	message       string
+	filePath      string
+	instruction   string
+	secret        string // masked in the instruction, so the result does not leak it
 	LocationRange LocationRange
 }
 
@@ -31,11 +41,13 @@ func (ruleValidationResult *RuleValidationResult) MarshalJSON() ([]byte, error)
 		Rule          *Rule         `json:"Rule"`
 		IsViolated    bool          `json:"IsViolated"`
 		Message       string        `json:"Message"`
+		FilePath      string        `json:"FilePath"`
 		LocationRange LocationRange `json:"LocationRange"`
 	}{
 		Rule:          ruleValidationResult.rule,
 		IsViolated:    ruleValidationResult.isViolated,
 		Message:       ruleValidationResult.Message(),
+		FilePath:      ruleValidationResult.filePath,
 		LocationRange: ruleValidationResult.LocationRange,
 	})
 }
@@ -45,6 +57,7 @@ func (ruleValidationResult *RuleValidationResult) UnmarshalJSON(data []byte) err
 		Rule          *Rule
 		IsViolated    bool
 		Message       string
+		FilePath      string
 		LocationRange LocationRange
 	}{}
 
@@ -56,6 +69,7 @@ func (ruleValidationResult *RuleValidationResult) UnmarshalJSON(data []byte) err
 	ruleValidationResult.rule = rvr.Rule
 	ruleValidationResult.isViolated = rvr.IsViolated
 	ruleValidationResult.message = rvr.Message
+	ruleValidationResult.filePath = rvr.FilePath
 	ruleValidationResult.LocationRange = rvr.LocationRange
 
 	return nil
@@ -100,10 +114,68 @@ func (ruleValidationResult *RuleValidationResult) SetLocationRangeFrom(locationR
 	ruleValidationResult.LocationRange = locationRange
 }
 
+func (ruleValidationResult *RuleValidationResult) Rule() *Rule {
+	return ruleValidationResult.rule
+}
+
 func (ruleValidationResult *RuleValidationResult) SetRule(rule *Rule) {
 	ruleValidationResult.rule = rule
 }
 
+// FilePath returns the path of the Dockerfile that the result belongs to. It's empty, if it's unknown.
+func (ruleValidationResult *RuleValidationResult) FilePath() string {
+	return ruleValidationResult.filePath
+}
+
+func (ruleValidationResult *RuleValidationResult) SetFilePath(filePath string) {
+	ruleValidationResult.filePath = filePath
+}
+
+// Instruction returns the source code of the Dockerfile instruction, that the result belongs to.
+func (ruleValidationResult *RuleValidationResult) Instruction() string {
+	return ruleValidationResult.instruction
+}
+
+// SetInstruction stores the source code of the instruction, with the secret found by the rule and the ones found by
+// the other rules of the same instruction (secretList) masked, so the result does not leak them.
+func (ruleValidationResult *RuleValidationResult) SetInstruction(instruction string, secretList ...string) {
+	secretList = append([]string{ruleValidationResult.secret}, secretList...)
+	// the longer secrets first, so a secret, that contains another one, is still masked as a whole
+	sort.SliceStable(secretList, func(i, j int) bool { return len(secretList[i]) > len(secretList[j]) })
+
+	for _, secret := range secretList {
+		if secret != "" {
+			instruction = strings.ReplaceAll(instruction, secret, maskSecret(secret))
+		}
+	}
+
+	ruleValidationResult.instruction = instruction
+}
+
+// Secret returns the secret found by the rule, e.g. a token of a SEC rule, or an empty string.
+func (ruleValidationResult *RuleValidationResult) Secret() string {
+	return ruleValidationResult.secret
+}
+
+// Fingerprint identifies the result by its rule ID, cleaned file path and normalized instruction source code. As line
+// numbers are not part of it, it's stable against unrelated changes of the Dockerfile, e.g. adding a line before. The
+// file path is hashed as it's set, so fingerprints compared across runs need it relative to a fixed directory, as the
+// baseline does.
+func (ruleValidationResult *RuleValidationResult) Fingerprint() string {
+	hash := sha256.New()
+
+	for _, str := range []string{
+		ruleValidationResult.RuleID(),
+		filepath.ToSlash(filepath.Clean(ruleValidationResult.filePath)),
+		Utils.RemoveExtraSpaces(ruleValidationResult.instruction, true),
+	} {
+		hash.Write([]byte(str))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func (ruleValidationResult *RuleValidationResult) Severity() Severity {
 	return ruleValidationResult.rule.Severity()
 }
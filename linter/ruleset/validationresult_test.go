@@ -4,14 +4,13 @@ import (
 	"encoding/json"
 	"testing"
 
-	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/stretchr/testify/assert"
 
 	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
 )
 
 func newMockRule() *RuleSet.Rule {
-	mockFunc := func(command *instructions.Command) /* RuleSet.RuleValidationResult */ {}
+	mockFunc := newMockValidator("*instructions.Command")
 	mockRule := RuleSet.NewRule("FakeID", "MockDef", "MockDesc", RuleSet.ValUnknown, mockFunc)
 
 	return mockRule
@@ -28,6 +27,7 @@ func TestRuleValidationResult_MarshalJSON(t *testing.T) {
 	mockLoc := newMockLocation()
 
 	referenceRuleValidationResult := RuleSet.NewRuleValidationResult(mockRule, false, "mockMessage", mockLoc)
+	referenceRuleValidationResult.SetFilePath("path/to/Dockerfile")
 	var duplicateRuleValidationResult RuleSet.RuleValidationResult // nolint:wsl
 
 	// serialize
@@ -194,6 +194,56 @@ func TestRuleValidationResult_SetLocationRangeFrom(t *testing.T) {
 	assert.Equal(t, mockLoc2, *ruleValidationResult.Location())
 }
 
+func TestRuleValidationResult_SetFilePath(t *testing.T) {
+	t.Parallel()
+
+	ruleValidationResult := RuleSet.NewRuleValidationResult(newMockRule(), false, "", newMockLocation())
+
+	assert.Equal(t, "", ruleValidationResult.FilePath())
+
+	ruleValidationResult.SetFilePath("path/to/Dockerfile")
+
+	assert.Equal(t, "path/to/Dockerfile", ruleValidationResult.FilePath())
+}
+
+func TestRuleValidationResult_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	newResult := func(filePath, instruction string, line int) *RuleSet.RuleValidationResult {
+		result := RuleSet.NewRuleValidationResult(newMockRule(), true, "", RuleSet.NewLocationRange(line, 0, line, 1))
+		result.SetFilePath(filePath)
+		result.SetInstruction(instruction)
+
+		return result
+	}
+
+	reference := newResult("./api/Dockerfile", "RUN apt-get install vim", 2)
+
+	assert.Equal(t, "RUN apt-get install vim", reference.Instruction())
+	assert.Equal(t, 64, len(reference.Fingerprint()))
+
+	// line shifts and whitespace changes keep the fingerprint
+	assert.Equal(t, reference.Fingerprint(), newResult("api/Dockerfile", "RUN apt-get  install \tvim ", 5).Fingerprint())
+
+	// different file or instruction changes it
+	assert.NotEqual(t, reference.Fingerprint(), newResult("Dockerfile", "RUN apt-get install vim", 2).Fingerprint())
+	assert.NotEqual(t, reference.Fingerprint(), newResult("api/Dockerfile", "RUN apt-get install git", 2).Fingerprint())
+}
+
+func TestRuleValidationResult_SetInstruction(t *testing.T) {
+	t.Parallel()
+
+	result := RuleSet.NewRuleValidationResult(newMockRule(), true, "", newMockLocation())
+	assert.Equal(t, "", result.Secret())
+
+	// the secrets found by the other rules of the instruction are masked too, the longer ones first
+	result.SetInstruction("ENV NPM_TOKEN=9f8e7d6c5b4a3f2e1d0c GH_TOKEN=9f8e7d6c", "9f8e7d6c", "9f8e7d6c5b4a3f2e1d0c")
+	assert.Equal(t, "ENV NPM_TOKEN=9f8e**** GH_TOKEN=9f****", result.Instruction())
+
+	result.SetInstruction("RUN make")
+	assert.Equal(t, "RUN make", result.Instruction())
+}
+
 // nolint:gofmt,gofumpt,goimports
 func TestRuleValidationResult_Message(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,117 @@
+package ruleset
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+// NodeKind identifies a kind of Dockerfile AST node, that rules validate, by its type name, e.g.
+// "*instructions.RunCommand". Besides the buildkit instructions, the whole stage list, the stages, the inline
+// suppression directives and the instructions, that buildkit cannot parse, are nodes too.
+type NodeKind string
+
+// NodeKindOf returns the kind of node.
+func NodeKindOf(node interface{}) NodeKind {
+	return NodeKind(fmt.Sprintf("%T", node))
+}
+
+// Validator validates the Dockerfile AST nodes of a single kind.
+type Validator interface {
+	// NodeKind returns the kind of nodes, that the validator handles.
+	NodeKind() NodeKind
+	// Validate validates node, which is of NodeKind, in the context of the linting run.
+	Validate(node interface{}, ctx *Context) RuleValidationResult
+}
+
+type validator struct {
+	nodeKind NodeKind
+	validate func(node interface{}, ctx *Context) RuleValidationResult
+}
+
+func (validator validator) NodeKind() NodeKind {
+	return validator.nodeKind
+}
+
+func (validator validator) Validate(node interface{}, ctx *Context) RuleValidationResult {
+	return validator.validate(node, ctx)
+}
+
+// NewValidator returns the Validator of the nodes of nodeKind, that calls validate.
+func NewValidator(nodeKind NodeKind,
+	validate func(node interface{}, ctx *Context) RuleValidationResult) Validator {
+	return validator{nodeKind: nodeKind, validate: validate}
+}
+
+// nolint:gochecknoglobals
+var (
+	commandType          = reflect.TypeOf((*instructions.Command)(nil)).Elem()
+	contextType          = reflect.TypeOf((*Context)(nil))
+	validationResultType = reflect.TypeOf(RuleValidationResult{}) // nolint:exhaustivestruct
+
+	// nodeTypeList are the node types besides the buildkit commands
+	nodeTypeList = []reflect.Type{
+		reflect.TypeOf(([]instructions.Stage)(nil)),
+		reflect.TypeOf(instructions.Stage{}), // nolint:exhaustivestruct
+		reflect.TypeOf((*Parser.Suppression)(nil)),
+		reflect.TypeOf((*Parser.InvalidInstruction)(nil)),
+	}
+)
+
+// ValidatorOf returns the Validator of a typed validation function, e.g.
+//
+//	func(runCommand *instructions.RunCommand) RuleValidationResult or
+//	func(runCommand *instructions.RunCommand, ctx *Context) RuleValidationResult
+//
+// whose node kind is the type of its first parameter, either a buildkit command or one of the other nodes. A Validator
+// is returned as is. It returns false for any other value, e.g. a function of an unsupported node kind.
+//
+// The node type is derived once, when the rule is created, so neither the rules, nor the linter have to dispatch on
+// the node types.
+func ValidatorOf(validationFunc interface{}) (Validator, bool) {
+	if validator, ok := validationFunc.(Validator); ok {
+		return validator, true
+	}
+
+	funcValue := reflect.ValueOf(validationFunc)
+	if funcValue.Kind() != reflect.Func {
+		return nil, false
+	}
+
+	funcType := funcValue.Type()
+	if funcType.IsVariadic() || funcType.NumIn() < 1 || funcType.NumIn() > 2 ||
+		(funcType.NumIn() == 2 && funcType.In(1) != contextType) ||
+		funcType.NumOut() != 1 || funcType.Out(0) != validationResultType || !isNodeType(funcType.In(0)) {
+		return nil, false
+	}
+
+	hasContext := funcType.NumIn() == 2
+
+	return NewValidator(NodeKind(funcType.In(0).String()),
+		func(node interface{}, ctx *Context) RuleValidationResult {
+			argList := []reflect.Value{reflect.ValueOf(node)}
+			if hasContext {
+				argList = append(argList, reflect.ValueOf(ctx))
+			}
+
+			return funcValue.Call(argList)[0].Interface().(RuleValidationResult) // nolint:forcetypeassert
+		}), true
+}
+
+// isNodeType checks, whether nodeType is the type of a Dockerfile AST node, that rules validate.
+func isNodeType(nodeType reflect.Type) bool {
+	if nodeType.Kind() == reflect.Ptr && nodeType.Implements(commandType) {
+		return true
+	}
+
+	for _, supportedNodeType := range nodeTypeList {
+		if nodeType == supportedNodeType {
+			return true
+		}
+	}
+
+	return false
+}
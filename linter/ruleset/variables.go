@@ -0,0 +1,84 @@
+package ruleset
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+)
+
+// variableReference is a reference to a build variable, i.e. an ARG or an ENV, e.g. $VERSION or ${VERSION:-1.0}.
+type variableReference struct {
+	name string
+	text string
+	// hasModifier tells, whether the reference handles the unset variable itself, e.g. ${VERSION:-1.0}.
+	hasModifier bool
+}
+
+// regexpVariableReference matches $NAME and ${NAME...}, including the escaping backslash, if any.
+var regexpVariableReference = regexp.MustCompile( // nolint:gochecknoglobals
+	`\\?\$(?:\{([A-Za-z_][A-Za-z0-9_]*)([^}]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// variableReferences returns the build variable references in str, skipping the escaped ones, e.g. \$HOME.
+func variableReferences(str string) []variableReference {
+	referenceList := make([]variableReference, 0)
+
+	for _, matchList := range regexpVariableReference.FindAllStringSubmatch(str, -1) {
+		if strings.HasPrefix(matchList[0], "\\") {
+			continue
+		}
+
+		reference := variableReference{name: matchList[1], text: matchList[0], hasModifier: matchList[2] != ""}
+		if reference.name == "" {
+			reference.name = matchList[3]
+		}
+
+		referenceList = append(referenceList, reference)
+	}
+
+	return referenceList
+}
+
+// isVariableSubstituted tells, whether Docker substitutes the build variables in the command. In RUN, CMD and
+// ENTRYPOINT, it's left to the shell, which also sees the environment variables of the base image, while ONBUILD
+// commands are substituted in the build of the child image.
+func isVariableSubstituted(command instructions.Command) bool {
+	switch command.(type) {
+	case *instructions.RunCommand, *instructions.CmdCommand, *instructions.EntrypointCommand,
+		*instructions.OnbuildCommand:
+		return false
+	default:
+		return true
+	}
+}
+
+// declaredVariables returns the names of the variables, that an ARG or an ENV command declares.
+func declaredVariables(command instructions.Command) []string {
+	nameList := make([]string, 0)
+
+	switch command := command.(type) {
+	case *instructions.ArgCommand:
+		for _, arg := range command.Args {
+			nameList = append(nameList, arg.Key)
+		}
+	case *instructions.EnvCommand:
+		for _, env := range command.Env {
+			nameList = append(nameList, env.Key)
+		}
+	}
+
+	return nameList
+}
+
+// regexpSecretName matches variable names, that look like they hold a secret, e.g. NPM_TOKEN or DB_PASSWORD.
+var regexpSecretName = regexp.MustCompile( // nolint:gochecknoglobals
+	`(^|_)(PASSWORD|PASSWD|SECRET|TOKEN|API_?KEY|PRIVATE_?KEY|ACCESS_?KEY|CREDENTIALS?)(_|$)`)
+
+// looksLikeSecretName tells, whether the variable name looks like it holds a secret. Names of files, that hold the
+// secret, e.g. PASSWORD_FILE, are fine.
+func looksLikeSecretName(name string) bool {
+	name = strings.ToUpper(name)
+
+	return regexpSecretName.MatchString(name) && !strings.HasSuffix(name, "_FILE") &&
+		!strings.HasSuffix(name, "_PATH")
+}
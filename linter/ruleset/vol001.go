@@ -0,0 +1,92 @@
+package ruleset
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	Parser "github.com/cremindes/whalelint/parser"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// VOL -> VOLUME.
+var _ = NewRule("VOL001", "Declare VOLUME after the RUN instructions, that write its data.", "If a RUN "+
+	"instruction changes the data of a volume after it has been declared, the change may be discarded, e.g. by the "+
+	"classic builder. Write the data first, then declare the VOLUME.",
+	ValWarning, ValidateVol001)
+
+// writingBinList are the binaries, whose path arguments are usually written to.
+var writingBinList = []string{ // nolint:gochecknoglobals
+	"chmod", "chown", "cp", "curl", "git", "install", "ln", "mkdir", "mv", "rm", "rsync", "tar", "tee", "touch",
+	"unzip", "wget",
+}
+
+func ValidateVol001(stage instructions.Stage, ctx *Context) RuleValidationResult {
+	result := RuleValidationResult{isViolated: false, LocationRange: BKRangeSliceToLocationRange(stage.Location)}
+
+	for i, command := range stage.Commands {
+		volumeCommand, ok := command.(*instructions.VolumeCommand)
+		if !ok {
+			continue
+		}
+
+		for _, laterCommand := range stage.Commands[i+1:] {
+			runCommand, ok := laterCommand.(*instructions.RunCommand)
+			if !ok {
+				continue
+			}
+
+			for _, volume := range volumeCommand.Volumes {
+				if target, ok := runWriteTarget(runCommand, volume); ok {
+					runLocationRange := LocationRangeFromCommand(runCommand)
+					lineNumber := runLocationRange.Start().LineNumber()
+
+					result.SetViolated()
+					result.message = "RUN on line " + strconv.Itoa(lineNumber) + " writes " + target +
+						" after VOLUME " + volume + " is declared."
+					result.LocationRange = ctx.ParseLocation(volume, volumeCommand.Location())
+
+					return result
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// runWriteTarget returns the path in the volume, that the RUN command writes, e.g. by cp or by a redirection.
+func runWriteTarget(runCommand *instructions.RunCommand, volume string) (string, bool) {
+	bashCommandChain := Parser.ParseBashCommandChain(runCommand)
+
+	for i, bashCommand := range bashCommandChain.BashCommandList {
+		// the target of a > redirection is parsed as the binary of the next command in the chain
+		isWriting := Utils.EqualsEither(bashCommand.Bin(), writingBinList) ||
+			i > 0 && bashCommandChain.OperatorList[i-1] == ">"
+		tokenList := strings.Fields(bashCommand.String())
+
+		for j, token := range tokenList {
+			isRedirected := strings.HasPrefix(token, ">") || j > 0 && tokenList[j-1] == ">>"
+			token = strings.TrimLeft(token, ">")
+
+			if (isWriting || isRedirected) && isPathInVolume(token, volume) {
+				return token, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// isPathInVolume tells, whether the absolute filePath is the volume or a path in it.
+func isPathInVolume(filePath string, volume string) bool {
+	if !path.IsAbs(filePath) {
+		return false
+	}
+
+	filePath, volume = path.Clean(filePath), path.Clean(volume)
+
+	return filePath == volume || strings.HasPrefix(filePath, strings.TrimSuffix(volume, "/")+"/")
+}
@@ -0,0 +1,54 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+func TestValidateVol001(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		IsViolation bool
+		ExampleName string
+		DocsContext string
+	}{
+		{
+			IsViolation: false,
+			ExampleName: "VOLUME after writing its data.",
+			DocsContext: "FROM postgres:14\nRUN mkdir -p /data/db\nVOLUME /data",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "VOLUME before writing its data.",
+			DocsContext: "FROM postgres:14\nVOLUME /data\nRUN mkdir -p /data/db",
+		},
+		{
+			IsViolation: true,
+			ExampleName: "VOLUME before redirecting to it.",
+			DocsContext: "FROM postgres:14\nVOLUME [\"/data\"]\nRUN echo init > /data/init.sql",
+		},
+		{
+			IsViolation: false,
+			ExampleName: "VOLUME before reading its data.",
+			DocsContext: "FROM postgres:14\nVOLUME /data\nRUN ls /data",
+		},
+	}
+
+	RuleSet.RegisterTestCaseDocs("VOL001", testCases)
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ExampleName, func(t *testing.T) {
+			t.Parallel()
+
+			stageList, ctx := parseDockerfile(t, testCase.DocsContext)
+
+			assert.Equal(t, testCase.IsViolation, RuleSet.ValidateVol001(stageList[0], ctx).IsViolated())
+		})
+	}
+}
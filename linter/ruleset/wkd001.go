@@ -1,7 +1,9 @@
 package ruleset
 
 import (
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 )
@@ -9,7 +11,55 @@ import (
 var _ = NewRule("WKD001", "WORKDIR should be an absolute path for clarity and reliability.", "", ValWarning,
 	ValidateWkd001)
 
-func ValidateWkd001(workdirCommand *instructions.WorkdirCommand) RuleValidationResult {
+var _ = RegisterFix("WKD001", "Make the WORKDIR path absolute.", FixWkd001)
+
+// FixWkd001 makes the path of the WORKDIR absolute by resolving it against the previous WORKDIRs of the stage. It
+// assumes that the stage starts in "/", i.e. the base image does not set its own WORKDIR.
+func FixWkd001(result *RuleValidationResult, rawStr string) []TextEdit {
+	lineList := strings.Split(rawStr, "\n")
+
+	startLine, endLine, ok := InstructionLineRange(lineList, result.LocationRange.Start().LineNumber())
+	if !ok {
+		return []TextEdit{}
+	}
+
+	instruction := strings.Join(lineList[startLine-1:endLine], "\n")
+
+	// the WORKDIR of an ONBUILD trigger depends on the child image, so it cannot be resolved
+	if regexpOnbuildPrefix.MatchString(instruction) {
+		return []TextEdit{}
+	}
+
+	keyword, workdir, ok := splitInstruction(instruction)
+	if !ok || strings.ContainsAny(workdir, "$\"'") {
+		return []TextEdit{}
+	}
+
+	// the previous WORKDIRs of the stage, backwards
+	for i := startLine - 1; i >= 1 && !filepath.IsAbs(workdir); i-- {
+		previousKeyword, previousArgStr, ok := splitInstruction(lineList[i-1])
+
+		switch {
+		case !ok:
+			continue
+		case strings.EqualFold(previousKeyword, "FROM"):
+			i = 0
+		case strings.EqualFold(previousKeyword, "WORKDIR"):
+			if strings.ContainsAny(previousArgStr, "$\"'") {
+				return []TextEdit{}
+			}
+
+			workdir = path.Join(previousArgStr, workdir)
+		}
+	}
+
+	return []TextEdit{{
+		LocationRange: NewLocationRange(startLine, 0, endLine, len(lineList[endLine-1])),
+		NewText:       keyword + " " + path.Join("/", workdir),
+	}}
+}
+
+func ValidateWkd001(workdirCommand *instructions.WorkdirCommand, ctx *Context) RuleValidationResult {
 	result := RuleValidationResult{
 		isViolated:    false,
 		LocationRange: LocationRangeFromCommand(workdirCommand),
@@ -17,7 +67,7 @@ func ValidateWkd001(workdirCommand *instructions.WorkdirCommand) RuleValidationR
 
 	if !filepath.IsAbs(workdirCommand.Path) {
 		result.SetViolated()
-		result.LocationRange = ParseLocationFromRawParser(workdirCommand.Path, workdirCommand.Location())
+		result.LocationRange = ctx.ParseLocation(workdirCommand.Path, workdirCommand.Location())
 	}
 
 	return result
@@ -13,17 +13,17 @@ func TestValidateDl3000(t *testing.T) {
 
 	absWorkdirCommand := &instructions.WorkdirCommand{Path: "/go"}
 
-	if RuleSet.ValidateWkd001(absWorkdirCommand).IsViolated() != false {
+	if RuleSet.ValidateWkd001(absWorkdirCommand, nil).IsViolated() != false {
 		t.Errorf("validateDf3000, a.k.a validate WORKDIR is absolute path, should pass for \"/go\"!")
 	}
 
 	nonAbsWorkdirCommand1 := &instructions.WorkdirCommand{Path: "./go"}
-	if RuleSet.ValidateWkd001(nonAbsWorkdirCommand1).IsViolated() != true {
+	if RuleSet.ValidateWkd001(nonAbsWorkdirCommand1, nil).IsViolated() != true {
 		t.Errorf("validateDf3000, a.k.a validate WORKDIR is absolute path, should not pass for \"./go\"!")
 	}
 
 	nonAbsWorkdirCommand2 := &instructions.WorkdirCommand{Path: "go/src"}
-	if RuleSet.ValidateWkd001(nonAbsWorkdirCommand2).IsViolated() != true {
+	if RuleSet.ValidateWkd001(nonAbsWorkdirCommand2, nil).IsViolated() != true {
 		t.Errorf("validateDf3000, a.k.a validate WORKDIR is absolute path, should not pass for \"go/src\"!")
 	}
 }
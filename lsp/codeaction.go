@@ -0,0 +1,97 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	Log "github.com/sirupsen/logrus"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// QuickFixCodeAction returns the code action fixing the violation reported in result and described by diagnostic, if
+// its rule is fixable. The fix is computed on rawStr, the content of the document.
+func QuickFixCodeAction(uri DocumentURI, rawStr string, result *RuleSet.RuleValidationResult,
+	diagnostic Diagnostic) (CodeAction, bool) {
+	rule := result.Rule()
+	if rule == nil || !rule.IsFixable() {
+		return CodeAction{}, false
+	}
+
+	editList := rule.Fix(result, rawStr)
+	if len(editList) == 0 {
+		return CodeAction{}, false
+	}
+
+	lineList := strings.Split(rawStr, "\n")
+	textEditList := make([]TextEdit, len(editList))
+
+	for i, edit := range editList {
+		textEditList[i] = TextEdit{
+			Range:   VSCodeRangeFromTextEditRange(edit.LocationRange, lineList),
+			NewText: edit.NewText,
+		}
+	}
+
+	return CodeAction{
+		Title:       rule.ID() + " | " + rule.FixTitle(),
+		Kind:        QuickFix,
+		Diagnostics: []Diagnostic{diagnostic},
+		IsPreferred: true,
+		Edit:        &WorkspaceEdit{Changes: map[DocumentURI][]TextEdit{uri: textEditList}},
+	}, true
+}
+
+// onCodeAction returns the quick fixes of the diagnostics, that overlap with the requested range.
+func (session *session) onCodeAction(params interface{}) (interface{}, error) {
+	codeActionParams := CodeActionParams{} // nolint:exhaustivestruct
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal codeAction params: %w", err)
+	}
+
+	if err := json.Unmarshal(paramsJSON, &codeActionParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal codeAction params: %w", err)
+	}
+
+	result := make([]CodeAction, 0)
+
+	if len(codeActionParams.Context.Only) > 0 && !isQuickFixRequested(codeActionParams.Context.Only) {
+		return result, nil
+	}
+
+	_, _, codeActionList, _ := session.documents.getDiagnostics(codeActionParams.TextDocument.URI)
+
+	for _, codeAction := range codeActionList {
+		if rangesOverlap(codeAction.Diagnostics[0].Range, codeActionParams.Range) {
+			result = append(result, codeAction)
+		}
+	}
+
+	Log.Debug("Code actions: ", len(result))
+
+	return result, nil
+}
+
+// isQuickFixRequested checks, whether quick fixes are among the requested kinds, e.g. "quickfix" or its base "".
+func isQuickFixRequested(kindList []CodeActionKind) bool {
+	for _, kind := range kindList {
+		if kind == "" || kind == QuickFix {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rangesOverlap checks, whether the two ranges have at least one common position.
+func rangesOverlap(range1, range2 Range) bool {
+	return !positionBefore(range1.End, range2.Start) && !positionBefore(range2.End, range1.Start)
+}
+
+func positionBefore(position1, position2 Position) bool {
+	return position1.Line < position2.Line ||
+		(position1.Line == position2.Line && position1.Character < position2.Character)
+}
@@ -0,0 +1,78 @@
+package lsp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	LSP "github.com/cremindes/whalelint/lsp"
+)
+
+func TestQuickFixCodeAction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name       string
+		RuleID     string
+		RawStr     string
+		LineNumber int
+		IsFixable  bool
+		NewText    string
+		Range      LSP.Range
+	}{
+		{
+			Name:   "RUN010 in a multi-line RUN.",
+			RuleID: "RUN010", RawStr: "FROM ubuntu:20.04\nRUN apt-get update && \\\n    apt install -y vim\n", LineNumber: 2,
+			IsFixable: true, NewText: "RUN apt-get update && \\\n    apt install --no-install-recommends -y vim",
+			Range: LSP.Range{Start: LSP.Position{Line: 1, Character: 0}, End: LSP.Position{Line: 2, Character: 22}},
+		},
+		{
+			Name:   "MTR001 with characters outside of the BMP.",
+			RuleID: "MTR001", RawStr: "MAINTAINER Jürgen 🐳", LineNumber: 1,
+			IsFixable: true, NewText: `LABEL maintainer="Jürgen 🐳"`,
+			Range: LSP.Range{Start: LSP.Position{Line: 0, Character: 0}, End: LSP.Position{Line: 0, Character: 20}},
+		},
+		{
+			Name:   "ENT001 with invalid JSON.",
+			RuleID: "ENT001", RawStr: `ENTRYPOINT ["/bin/bash" "date"`, LineNumber: 1,
+			IsFixable: false,
+		},
+		{
+			Name:   "Not fixable rule.",
+			RuleID: "STS001", RawStr: "FROM golang", LineNumber: 1,
+			IsFixable: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := RuleSet.Get().GetRuleByName(testCase.RuleID, nil)
+			locationRange := RuleSet.NewLocationRange(testCase.LineNumber, 0, testCase.LineNumber, 0)
+			result := RuleSet.NewRuleValidationResult(&rule, true, "", locationRange)
+			diagnostic := LSP.Diagnostic{ // nolint:exhaustivestruct
+				Range: LSP.VSCodeRangeFromLocationRange(locationRange),
+				Code:  testCase.RuleID,
+			}
+
+			codeAction, ok := LSP.QuickFixCodeAction("file:///Dockerfile", testCase.RawStr, result, diagnostic)
+
+			assert.Equal(t, testCase.IsFixable, ok)
+
+			if !testCase.IsFixable {
+				return
+			}
+
+			assert.Equal(t, LSP.QuickFix, codeAction.Kind)
+			assert.Equal(t, []LSP.Diagnostic{diagnostic}, codeAction.Diagnostics)
+			assert.True(t, strings.HasPrefix(codeAction.Title, testCase.RuleID))
+			assert.Equal(t, []LSP.TextEdit{{Range: testCase.Range, NewText: testCase.NewText}},
+				codeAction.Edit.Changes["file:///Dockerfile"])
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package lsp
 
 import (
+	"unicode/utf16"
+
 	Log "github.com/sirupsen/logrus"
 
 	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
@@ -26,6 +28,24 @@ func VSCodeRangeFromLocationRange(lr RuleSet.LocationRange) Range {
 	return r
 }
 
+// VSCodeRangeFromTextEditRange converts the range of a RuleSet.TextEdit to VS Code's Range Go equivalent type. Unlike
+// the rule validation results, the character numbers of text edits are byte offsets, so they are converted to UTF-16
+// code units, as LSP expects, based on lineList, the lines of the document.
+func VSCodeRangeFromTextEditRange(lr RuleSet.LocationRange, lineList []string) Range {
+	position := func(location *RuleSet.Location) Position {
+		line := location.LineNumber() - 1
+		character := location.CharNumber()
+
+		if line >= 0 && line < len(lineList) && character <= len(lineList[line]) {
+			character = len(utf16.Encode([]rune(lineList[line][:character])))
+		}
+
+		return Position{Line: float64(line), Character: float64(character)}
+	}
+
+	return Range{Start: position(lr.Start()), End: position(lr.End())}
+}
+
 // VSCodeSeverityFromSeverity convert RuleSet.Severity to VS Code's Severity Go equivalent type.
 func VSCodeSeverityFromSeverity(s RuleSet.Severity) DiagnosticSeverity {
 	switch s {
@@ -0,0 +1,96 @@
+package lsp
+
+import (
+	"sync"
+	"time"
+)
+
+// LintDebounceDelay is how long the server waits after the last change of a document, before linting it. This way
+// typing in a large Dockerfile does not re-parse and re-lint it on every keystroke.
+const LintDebounceDelay = 300 * time.Millisecond
+
+// lintScheduler holds the scheduled lintings of a session per document.
+type lintScheduler struct {
+	pendingLintMap map[DocumentURI]*time.Timer
+	lock           sync.Mutex
+	// lint lints the document of uri and publishes its diagnostics
+	lint func(uri DocumentURI)
+}
+
+func newLintScheduler(lint func(uri DocumentURI)) *lintScheduler {
+	return &lintScheduler{ // nolint:exhaustivestruct
+		pendingLintMap: map[DocumentURI]*time.Timer{},
+		lint:           lint,
+	}
+}
+
+// schedule lints the document of uri after LintDebounceDelay, postponing the earlier scheduled linting, if any.
+func (scheduler *lintScheduler) schedule(uri DocumentURI) {
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+
+	if timer, ok := scheduler.pendingLintMap[uri]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+
+	timer = time.AfterFunc(LintDebounceDelay, func() {
+		scheduler.lock.Lock()
+		// it has been flushed or rescheduled meanwhile
+		if pendingTimer, ok := scheduler.pendingLintMap[uri]; !ok || pendingTimer != timer {
+			scheduler.lock.Unlock()
+
+			return
+		}
+
+		delete(scheduler.pendingLintMap, uri)
+		scheduler.lock.Unlock()
+
+		scheduler.lint(uri)
+	})
+
+	scheduler.pendingLintMap[uri] = timer
+}
+
+// cancel drops the scheduled linting of the document of uri, e.g. because it has been closed.
+func (scheduler *lintScheduler) cancel(uri DocumentURI) {
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+
+	if timer, ok := scheduler.pendingLintMap[uri]; ok {
+		timer.Stop()
+		delete(scheduler.pendingLintMap, uri)
+	}
+}
+
+// flush lints the document of uri right away, if it has a scheduled linting, e.g. because a request needs its
+// up-to-date diagnostics.
+func (scheduler *lintScheduler) flush(uri DocumentURI) {
+	scheduler.lock.Lock()
+	timer, ok := scheduler.pendingLintMap[uri]
+	delete(scheduler.pendingLintMap, uri)
+	scheduler.lock.Unlock()
+
+	// a timer, that has fired meanwhile, finds its linting removed, so it's published once
+	if ok {
+		timer.Stop()
+		scheduler.lint(uri)
+	}
+}
+
+// flushAll lints every document with a scheduled linting, e.g. before the connection is closed.
+func (scheduler *lintScheduler) flushAll() {
+	scheduler.lock.Lock()
+
+	uriList := make([]DocumentURI, 0, len(scheduler.pendingLintMap))
+	for uri := range scheduler.pendingLintMap {
+		uriList = append(uriList, uri)
+	}
+
+	scheduler.lock.Unlock()
+
+	for _, uri := range uriList {
+		scheduler.flush(uri)
+	}
+}
@@ -0,0 +1,122 @@
+package lsp
+
+import (
+	"sync"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// Document is an open text document, as synced by the client.
+type Document struct {
+	URI       DocumentURI
+	Version   float64
+	Text      string
+	StageList []instructions.Stage
+	MetaArgs  []instructions.ArgCommand
+}
+
+// ChangedDocument is a document, that the client has changed, so its diagnostics are re-published after a while.
+type ChangedDocument struct {
+	URI DocumentURI
+}
+
+// ClosedDocument is a document, that the client has closed, so its diagnostics are cleared.
+type ClosedDocument struct {
+	URI DocumentURI
+}
+
+// storedDocument is an open document with the violations and the quick fixes of its last published diagnostics.
+type storedDocument struct {
+	document       Document
+	violationList  []RuleSet.RuleValidationResult
+	codeActionList []CodeAction
+}
+
+// documentStore holds the open documents of a session per URI.
+type documentStore struct {
+	documentMap map[DocumentURI]*storedDocument
+	lock        sync.Mutex
+}
+
+func newDocumentStore() *documentStore {
+	return &documentStore{ // nolint:exhaustivestruct
+		documentMap: map[DocumentURI]*storedDocument{},
+	}
+}
+
+// store adds the document to the store, or replaces its earlier version.
+func (store *documentStore) store(document Document) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	store.documentMap[document.URI] = &storedDocument{document: document}
+}
+
+// get returns the open document of uri.
+func (store *documentStore) get(uri DocumentURI) (Document, bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	storedDocument, ok := store.documentMap[uri]
+	if !ok {
+		return Document{}, false
+	}
+
+	return storedDocument.document, true
+}
+
+// list returns the open documents.
+func (store *documentStore) list() []Document {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	documentList := make([]Document, 0, len(store.documentMap))
+	for _, storedDocument := range store.documentMap {
+		documentList = append(documentList, storedDocument.document)
+	}
+
+	return documentList
+}
+
+// remove removes the document of uri from the store.
+func (store *documentStore) remove(uri DocumentURI) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	delete(store.documentMap, uri)
+}
+
+// storeDiagnostics stores the parsed stages, the violations and the quick fixes of the document, unless it has been
+// closed or changed since, i.e. they are outdated.
+func (store *documentStore) storeDiagnostics(document Document, violationList []RuleSet.RuleValidationResult,
+	codeActionList []CodeAction) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	storedDocument, ok := store.documentMap[document.URI]
+	if !ok || storedDocument.document.Version != document.Version {
+		return
+	}
+
+	storedDocument.document.StageList = document.StageList
+	storedDocument.document.MetaArgs = document.MetaArgs
+	storedDocument.violationList = violationList
+	storedDocument.codeActionList = codeActionList
+}
+
+// getDiagnostics returns the document of uri with the violations and the quick fixes of its last published
+// diagnostics.
+func (store *documentStore) getDiagnostics(uri DocumentURI) (Document, []RuleSet.RuleValidationResult, []CodeAction,
+	bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	storedDocument, ok := store.documentMap[uri]
+	if !ok {
+		return Document{}, nil, nil, false
+	}
+
+	return storedDocument.document, storedDocument.violationList, storedDocument.codeActionList, true
+}
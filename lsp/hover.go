@@ -0,0 +1,181 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// instructionDocs is the summary of a Dockerfile instruction from the Dockerfile reference.
+type instructionDocs struct {
+	summary string
+	anchor  string
+}
+
+// dockerfileReferenceURL is the Dockerfile reference, that the instruction anchors are relative to.
+const dockerfileReferenceURL = "https://docs.docker.com/engine/reference/builder/"
+
+// nolint:gochecknoglobals,lll
+var instructionDocsMap = map[string]instructionDocs{
+	"ADD":         {"Copies new files, directories or remote file URLs from `<src>` and adds them to the filesystem of the image at the path `<dest>`. Local tar archives are extracted.", "#add"},
+	"ARG":         {"Defines a variable that users can pass at build-time to the builder with the `docker build` command using the `--build-arg <varname>=<value>` flag.", "#arg"},
+	"CMD":         {"Provides defaults for an executing container. There can only be one CMD instruction in a Dockerfile, only the last one takes effect.", "#cmd"},
+	"COPY":        {"Copies new files or directories from `<src>` and adds them to the filesystem of the container at the path `<dest>`.", "#copy"},
+	"ENTRYPOINT":  {"Configures a container that will run as an executable.", "#entrypoint"},
+	"ENV":         {"Sets the environment variable `<key>` to the value `<value>`. The value is in the environment for all subsequent instructions in the build stage and persists in the image.", "#env"},
+	"EXPOSE":      {"Informs Docker that the container listens on the specified network ports at runtime. It does not actually publish the port.", "#expose"},
+	"FROM":        {"Initializes a new build stage and sets the base image for subsequent instructions.", "#from"},
+	"HEALTHCHECK": {"Tells Docker how to test a container to check that it is still working.", "#healthcheck"},
+	"LABEL":       {"Adds metadata to an image. A LABEL is a key-value pair.", "#label"},
+	"MAINTAINER":  {"Sets the Author field of the generated images. Deprecated, use a LABEL instead.", "#maintainer-deprecated"},
+	"ONBUILD":     {"Adds a trigger instruction to the image, to be executed at a later time, when the image is used as the base for another build.", "#onbuild"},
+	"RUN":         {"Executes any commands in a new layer on top of the current image and commits the results.", "#run"},
+	"SHELL":       {"Allows the default shell used for the shell form of commands to be overridden.", "#shell"},
+	"STOPSIGNAL":  {"Sets the system call signal that will be sent to the container to exit.", "#stopsignal"},
+	"USER":        {"Sets the user name or UID, and optionally the user group or GID, to use for the rest of the current stage.", "#user"},
+	"VOLUME":      {"Creates a mount point with the specified name and marks it as holding externally mounted volumes from native host or other containers.", "#volume"},
+	"WORKDIR":     {"Sets the working directory for any RUN, CMD, ENTRYPOINT, COPY and ADD instructions that follow it in the Dockerfile.", "#workdir"},
+}
+
+// onHover shows the documentation of the rules violated at the hovered position, and the Dockerfile reference summary
+// of the hovered instruction keyword.
+func (session *session) onHover(params interface{}) (interface{}, error) {
+	hoverParams := HoverParams{} // nolint:exhaustivestruct
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hover params: %w", err)
+	}
+
+	if err := json.Unmarshal(paramsJSON, &hoverParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal hover params: %w", err)
+	}
+
+	document, violationList, _, ok := session.documents.getDiagnostics(hoverParams.TextDocument.URI)
+	if !ok {
+		return nil, nil
+	}
+
+	return HoverAt(strings.Split(document.Text, "\n"), violationList, hoverParams.Position), nil
+}
+
+// HoverAt returns the hover content at position of the document, given by its lines and violations, or nil if there
+// is nothing to show.
+func HoverAt(lineList []string, violationList []RuleSet.RuleValidationResult, position Position) *Hover {
+	sectionList := make([]string, 0)
+
+	for i := range violationList {
+		// the validation results span whole lines, so only the line is compared
+		locationRange := VSCodeRangeFromLocationRange(violationList[i].LocationRange)
+		if locationRange.Start.Line <= position.Line && position.Line <= locationRange.End.Line {
+			sectionList = append(sectionList, ruleHoverMarkdown(violationList[i].Rule()))
+		}
+	}
+
+	keyword, keywordRange, ok := keywordAt(lineList, position)
+	if ok {
+		docs := instructionDocsMap[keyword]
+		sectionList = append(sectionList,
+			"**"+keyword+"**\n\n"+docs.summary+"\n\n[Dockerfile reference]("+dockerfileReferenceURL+docs.anchor+")")
+	}
+
+	if len(sectionList) == 0 {
+		return nil
+	}
+
+	hover := &Hover{
+		Contents: MarkupContent{Kind: Markdown, Value: strings.Join(sectionList, "\n\n---\n\n")},
+		Range:    nil,
+	}
+
+	if ok {
+		hover.Range = &keywordRange
+	}
+
+	return hover
+}
+
+// ruleHoverMarkdown returns the definition, description, an example and the reference of the rule in markdown.
+func ruleHoverMarkdown(rule *RuleSet.Rule) string {
+	strBuilder := &strings.Builder{}
+
+	strBuilder.WriteString("**" + rule.ID() + "** | " + rule.Severity().String() + "\n\n")
+	strBuilder.WriteString(rule.Definition())
+
+	if rule.Description() != "" {
+		strBuilder.WriteString("\n\n" + rule.Description())
+	}
+
+	// the first bad and good examples
+	for _, isViolation := range []bool{true, false} {
+		for _, example := range RuleSet.GetExamples(rule.ID()) {
+			if example.IsViolation != isViolation {
+				continue
+			}
+
+			label := "Good"
+			if example.IsViolation {
+				label = "Bad"
+			}
+
+			strBuilder.WriteString("\n\n" + label + ":\n")
+			strBuilder.WriteString("```dockerfile\n" + strings.TrimSpace(example.DocsContext) + "\n```")
+
+			break
+		}
+	}
+
+	if href, ok := docsReferenceHref(rule.DocsReference()); ok {
+		strBuilder.WriteString("\n\n[Reference](" + href + ")")
+	}
+
+	return strBuilder.String()
+}
+
+// docsReferenceHref returns the link of the docs reference, if it has one.
+func docsReferenceHref(docsReference RuleSet.DocsReference) (URI, bool) {
+	href := string(docsReference)
+
+	return href, strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://")
+}
+
+// keywordAt returns the instruction keyword at position and its range, if any. Continuation lines of multi-line
+// instructions, e.g. "RUN apt-get update && \", do not start with a keyword.
+func keywordAt(lineList []string, position Position) (string, Range, bool) {
+	line := int(position.Line)
+	if line < 0 || line >= len(lineList) {
+		return "", Range{}, false
+	}
+
+	if line > 0 && strings.HasSuffix(strings.TrimRight(lineList[line-1], " \t\r"), "\\") {
+		return "", Range{}, false
+	}
+
+	lineStr := lineList[line]
+	trimmedLine := strings.TrimLeft(lineStr, " \t")
+
+	fieldList := strings.Fields(trimmedLine)
+	if len(fieldList) == 0 {
+		return "", Range{}, false
+	}
+
+	keyword := strings.ToUpper(fieldList[0])
+	if _, ok := instructionDocsMap[keyword]; !ok {
+		return "", Range{}, false
+	}
+
+	// only whitespace precedes the keyword, so byte offsets equal UTF-16 offsets
+	start := float64(len(lineStr) - len(trimmedLine))
+	end := start + float64(len(keyword))
+
+	if position.Character < start || position.Character > end {
+		return "", Range{}, false
+	}
+
+	return keyword, Range{
+		Start: Position{Line: position.Line, Character: start},
+		End:   Position{Line: position.Line, Character: end},
+	}, true
+}
@@ -0,0 +1,84 @@
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	LSP "github.com/cremindes/whalelint/lsp"
+)
+
+func TestHoverAt(t *testing.T) {
+	t.Parallel()
+
+	lineList := []string{"FROM golang", "RUN apt-get update && \\", "    apt-get install -y vim", "  workdir /app"}
+
+	rule := RuleSet.Get().GetRuleByName("RUN006", nil)
+	violationList := []RuleSet.RuleValidationResult{
+		*RuleSet.NewRuleValidationResult(&rule, true, "", RuleSet.NewLocationRange(2, 0, 3, 0)),
+	}
+
+	testCases := []struct {
+		Name             string
+		Position         LSP.Position
+		ExpectedContains []string
+		ExpectedRange    *LSP.Range
+	}{
+		{
+			Name:             "Keyword without violation.",
+			Position:         LSP.Position{Line: 0, Character: 2},
+			ExpectedContains: []string{"**FROM**", "https://docs.docker.com/engine/reference/builder/#from"},
+			ExpectedRange: &LSP.Range{
+				Start: LSP.Position{Line: 0, Character: 0}, End: LSP.Position{Line: 0, Character: 4},
+			},
+		},
+		{
+			Name:             "Indented lowercase keyword.",
+			Position:         LSP.Position{Line: 3, Character: 9},
+			ExpectedContains: []string{"**WORKDIR**"},
+			ExpectedRange: &LSP.Range{
+				Start: LSP.Position{Line: 3, Character: 2}, End: LSP.Position{Line: 3, Character: 9},
+			},
+		},
+		{
+			Name:     "Keyword with violation.",
+			Position: LSP.Position{Line: 1, Character: 1},
+			ExpectedContains: []string{
+				"**RUN006** | Warning", rule.Definition(), "```dockerfile", "**RUN**",
+				"[Reference](https://docs.docker.com/engine/reference/builder/#run)",
+			},
+			ExpectedRange: &LSP.Range{
+				Start: LSP.Position{Line: 1, Character: 0}, End: LSP.Position{Line: 1, Character: 3},
+			},
+		},
+		{
+			Name:             "Continuation line of a violation.",
+			Position:         LSP.Position{Line: 2, Character: 6},
+			ExpectedContains: []string{"**RUN006** | Warning"},
+			ExpectedRange:    nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			hover := LSP.HoverAt(lineList, violationList, testCase.Position)
+			assert.NotNil(t, hover)
+			assert.Equal(t, LSP.Markdown, hover.Contents.Kind)
+
+			for _, expected := range testCase.ExpectedContains {
+				assert.Contains(t, hover.Contents.Value, expected)
+			}
+
+			assert.Equal(t, testCase.ExpectedRange, hover.Range)
+		})
+	}
+
+	// nothing to show after the keyword or out of the document
+	assert.Nil(t, LSP.HoverAt(lineList, []RuleSet.RuleValidationResult{}, LSP.Position{Line: 0, Character: 7}))
+	assert.Nil(t, LSP.HoverAt(lineList, violationList, LSP.Position{Line: 9, Character: 0}))
+}
@@ -0,0 +1,111 @@
+package lsp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrorCode is the code of a JSONRPC error object.
+type ErrorCode int
+
+// The error codes of JSONRPC and the ones the Language Server Protocol adds to them.
+const (
+	ParseError           ErrorCode = -32700
+	InvalidRequest       ErrorCode = -32600
+	MethodNotFound       ErrorCode = -32601
+	InvalidParams        ErrorCode = -32602
+	InternalError        ErrorCode = -32603
+	ServerNotInitialized ErrorCode = -32002
+	RequestCancelled     ErrorCode = -32800
+)
+
+// ResponseError is the error object of a response to a request, that has failed.
+type ResponseError struct {
+	Code    ErrorCode   `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (responseError *ResponseError) Error() string {
+	return "JSONRPC error " + strconv.Itoa(int(responseError.Code)) + ": " + responseError.Message
+}
+
+// responseErrorFrom returns the error object of err. Errors, that are not error objects yet, are internal errors.
+func responseErrorFrom(err error) *ResponseError {
+	var responseError *ResponseError
+	if errors.As(err, &responseError) {
+		return responseError
+	}
+
+	return &ResponseError{Code: InternalError, Message: err.Error(), Data: nil}
+}
+
+var (
+	ErrInvalidHeader        = errors.New("invalid JSONRPC header")
+	ErrMissingContentLength = errors.New("missing Content-Length JSONRPC header")
+)
+
+// ReadMessage reads the content of the next JSONRPC message from reader.
+//
+// The base protocol consists of a header and a content part (comparable to HTTP). The header fields are separated from
+// each other and from the content part by '\r\n'. Only Content-Length is required, the others, e.g. Content-Type, are
+// ignored.
+//
+//	Content-Length: ...\r\n
+//	\r\n
+//	[content part]
+//
+// It returns io.EOF, if reader is closed between two messages. As a malformed header leaves no way to find the start of
+// the next message, the stream cannot be read further after any error.
+func ReadMessage(reader *bufio.Reader) ([]byte, error) {
+	contentLength := -1
+	isFirstLine := true
+
+	for {
+		line, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) && isFirstLine && line == "" {
+			return nil, io.EOF
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JSONRPC header: %w", err)
+		}
+
+		isFirstLine = false
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		header := strings.SplitN(line, ":", 2) // nolint:gomnd
+		if len(header) != 2 {                  // nolint:gomnd
+			return nil, fmt.Errorf("%w: %q", ErrInvalidHeader, line)
+		}
+
+		// header field names are case-insensitive, as in HTTP
+		if !strings.EqualFold(strings.TrimSpace(header[0]), "Content-Length") {
+			continue
+		}
+
+		contentLength, err = strconv.Atoi(strings.TrimSpace(header[1]))
+		if err != nil || contentLength < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidHeader, line)
+		}
+	}
+
+	if contentLength < 0 {
+		return nil, ErrMissingContentLength
+	}
+
+	content := make([]byte, contentLength)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return nil, fmt.Errorf("failed to read JSONRPC content: %w", err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,114 @@
+package lsp_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	LSP "github.com/cremindes/whalelint/lsp"
+)
+
+func TestReadMessage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name            string
+		Input           string
+		ExpectedContent string
+		ExpectedErr     error
+	}{
+		{
+			Name:            "Content-Length only.",
+			Input:           "Content-Length: 2\r\n\r\n{}",
+			ExpectedContent: "{}",
+			ExpectedErr:     nil,
+		},
+		{
+			Name: "Further headers in any order and case.",
+			Input: "Content-Type: application/vscode-jsonrpc; charset=utf-8\r\ncontent-length:2\r\n" +
+				"X-Custom: a:b\r\n\r\n{}",
+			ExpectedContent: "{}",
+			ExpectedErr:     nil,
+		},
+		{
+			Name:            "Line feed without carriage return.",
+			Input:           "Content-Length: 2\n\n{}",
+			ExpectedContent: "{}",
+			ExpectedErr:     nil,
+		},
+		{
+			Name:            "Closed input.",
+			Input:           "",
+			ExpectedContent: "",
+			ExpectedErr:     io.EOF,
+		},
+		{
+			Name:            "Closed input within the header.",
+			Input:           "Content-Length: 2\r\n",
+			ExpectedContent: "",
+			ExpectedErr:     io.EOF,
+		},
+		{
+			Name:            "Closed input within the content.",
+			Input:           "Content-Length: 4\r\n\r\n{}",
+			ExpectedContent: "",
+			ExpectedErr:     io.ErrUnexpectedEOF,
+		},
+		{
+			Name:            "Missing Content-Length.",
+			Input:           "Content-Type: application/vscode-jsonrpc\r\n\r\n{}",
+			ExpectedContent: "",
+			ExpectedErr:     LSP.ErrMissingContentLength,
+		},
+		{
+			Name:            "Invalid Content-Length.",
+			Input:           "Content-Length: -2\r\n\r\n{}",
+			ExpectedContent: "",
+			ExpectedErr:     LSP.ErrInvalidHeader,
+		},
+		{
+			Name:            "Header without value.",
+			Input:           "{}\r\n\r\n",
+			ExpectedContent: "",
+			ExpectedErr:     LSP.ErrInvalidHeader,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := LSP.ReadMessage(bufio.NewReader(strings.NewReader(testCase.Input)))
+
+			assert.Equal(t, testCase.ExpectedContent, string(content))
+
+			if testCase.ExpectedErr == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, testCase.ExpectedErr), err)
+			}
+		})
+	}
+}
+
+func TestReadMessage_Consecutive(t *testing.T) {
+	t.Parallel()
+
+	reader := bufio.NewReader(strings.NewReader("Content-Length: 1\r\n\r\n1Content-Length: 1\r\n\r\n2"))
+
+	for _, expectedContent := range []string{"1", "2"} {
+		content, err := LSP.ReadMessage(reader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedContent, string(content))
+	}
+
+	_, err := LSP.ReadMessage(reader)
+	assert.Equal(t, io.EOF, err)
+}
@@ -45,8 +45,8 @@ type RPCRequest struct {
 type RPCResponse struct {
 	ID interface{} `json:"id"`
 	//	JSONRpcV string      `json:"jsonrpc"` // no need to have/set this fields, as it's constant at the moment.
-	Result interface{} `json:"result"`
-	Err    interface{} `json:"error"`
+	Result interface{}    `json:"result"`
+	Err    *ResponseError `json:"error"`
 }
 
 type RPCNotification struct {
@@ -54,6 +54,7 @@ type RPCNotification struct {
 	Params interface{} `json:"params"`
 }
 
+// MarshalJSON marshals either the result or the error of the response, as they must not be present at the same time.
 func (r *RPCResponse) MarshalJSON() ([]byte, error) {
 	if r.Err == nil {
 		res := struct {
@@ -69,7 +70,17 @@ func (r *RPCResponse) MarshalJSON() ([]byte, error) {
 		return json.Marshal(res)
 	}
 
-	return json.Marshal(r)
+	res := struct {
+		JSONRpcV string         `json:"jsonrpc"`
+		ID       interface{}    `json:"id"`
+		Err      *ResponseError `json:"error"`
+	}{
+		JSONRpcV: "2.0",
+		ID:       r.ID,
+		Err:      r.Err,
+	}
+
+	return json.Marshal(res)
 }
 
 type InitializeResult struct {
@@ -88,6 +99,16 @@ type ServerCapabilities = struct {
 	 * for backwards compatibility the TextDocumentSyncKind number.
 	 */
 	TextDocumentSync interface{} /*TextDocumentSyncOptions | TextDocumentSyncKind*/ `json:"textDocumentSync,omitempty"`
+	/**
+	 * The server provides code actions. CodeActionOptions may only be
+	 * specified if the client states that it supports
+	 * `codeActionLiteralSupport` in its initial `initialize` request.
+	 */
+	CodeActionProvider interface{} /*boolean | CodeActionOptions*/ `json:"codeActionProvider,omitempty"`
+	/**
+	 * The server provides hover support.
+	 */
+	HoverProvider bool `json:"hoverProvider,omitempty"`
 }
 
 /**
@@ -453,3 +474,328 @@ const (
 	 * A textual occurrence.
 	 */
 )
+
+/**
+ * The parameters of a [CodeActionRequest](#CodeActionRequest).
+ */
+type CodeActionParams struct {
+	/**
+	 * The document in which the command was invoked.
+	 */
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	/**
+	 * The range for which the command was invoked.
+	 */
+	Range Range `json:"range"`
+	/**
+	 * Context carrying additional information.
+	 */
+	Context CodeActionContext `json:"context"`
+}
+
+/**
+ * Contains additional diagnostic information about the context in which
+ * a [code action](#CodeActionProvider.provideCodeActions) is run.
+ */
+type CodeActionContext struct {
+	/**
+	 * An array of diagnostics known on the client side overlapping the range provided to the
+	 * `textDocument/codeAction` request. They are provided so that the server knows which
+	 * errors are currently presented to the user for the given range. There is no guarantee
+	 * that these accurately reflect the error state of the resource. The primary parameter
+	 * to compute code actions is the provided range.
+	 */
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	/**
+	 * Requested kind of actions to return.
+	 *
+	 * Actions not of this kind are filtered out by the client before being shown. So servers
+	 * can omit computing them.
+	 */
+	Only []CodeActionKind `json:"only,omitempty"`
+}
+
+/**
+ * The kind of a code action.
+ *
+ * Kinds are a hierarchical list of identifiers separated by `.`, e.g. `"refactor.extract.function"`.
+ *
+ * The set of kinds is open and client needs to announce the kinds it supports to the server during
+ * initialization.
+ */
+type CodeActionKind string
+
+/**
+ * Base kind for quickfix actions: 'quickfix'
+ */
+const QuickFix CodeActionKind = "quickfix"
+
+/**
+ * A code action represents a change that can be performed in code, e.g. to fix a problem or
+ * to refactor code.
+ *
+ * A CodeAction must set either `edit` and/or a `command`. If both are supplied, the `edit` is applied first, then the
+ * `command` is executed.
+ */
+type CodeAction struct {
+	/**
+	 * A short, human-readable, title for this code action.
+	 */
+	Title string `json:"title"`
+	/**
+	 * The kind of the code action.
+	 *
+	 * Used to filter code actions.
+	 */
+	Kind CodeActionKind `json:"kind,omitempty"`
+	/**
+	 * The diagnostics that this code action resolves.
+	 */
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+	/**
+	 * Marks this as a preferred action. Preferred actions are used by the `auto fix` command and can be targeted
+	 * by keybindings.
+	 *
+	 * A quick fix should be marked preferred if it properly addresses the underlying error.
+	 * A refactoring should be marked preferred if it is the most reasonable choice of actions to take.
+	 *
+	 * @since 3.15.0
+	 */
+	IsPreferred bool `json:"isPreferred,omitempty"`
+	/**
+	 * The workspace edit this code action performs.
+	 */
+	Edit *WorkspaceEdit `json:"edit,omitempty"`
+}
+
+/**
+ * A workspace edit represents changes to many resources managed in the workspace. The edit
+ * should either provide `changes` or `documentChanges`. If documentChanges are present
+ * they are preferred over `changes` if the client can handle versioned document edits.
+ */
+type WorkspaceEdit struct {
+	/**
+	 * Holds changes to existing resources.
+	 */
+	Changes map[DocumentURI][]TextEdit `json:"changes,omitempty"`
+}
+
+/**
+ * A text edit applicable to a text document.
+ */
+type TextEdit struct {
+	/**
+	 * The range of the text document to be manipulated. To insert
+	 * text into a document create a range where start === end.
+	 */
+	Range Range `json:"range"`
+	/**
+	 * The string to be inserted. For delete operations use an
+	 * empty string.
+	 */
+	NewText string `json:"newText"`
+}
+
+/**
+ * Parameters for a [HoverRequest](#HoverRequest).
+ */
+type HoverParams struct {
+	/**
+	 * The text document.
+	 */
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	/**
+	 * The position inside the text document.
+	 */
+	Position Position `json:"position"`
+}
+
+/**
+ * The result of a hover request.
+ */
+type Hover struct {
+	/**
+	 * The hover's content
+	 */
+	Contents MarkupContent `json:"contents"`
+	/**
+	 * An optional range
+	 */
+	Range *Range `json:"range,omitempty"`
+}
+
+/**
+ * A `MarkupContent` literal represents a string value which content is interpreted base on its
+ * kind flag. Currently the protocol supports `plaintext` and `markdown` as markup kinds.
+ */
+type MarkupContent struct {
+	/**
+	 * The type of the Markup
+	 */
+	Kind MarkupKind `json:"kind"`
+	/**
+	 * The content itself
+	 */
+	Value string `json:"value"`
+}
+
+/**
+ * Describes the content type that a client supports in various
+ * result literals like `Hover`, `ParameterInfo` or `CompletionItem`.
+ */
+type MarkupKind string
+
+/**
+ * Markdown is supported as a content format
+ */
+const Markdown MarkupKind = "markdown"
+
+/**
+ * The initialize parameters, as far as the server uses them.
+ */
+type InitializeParams struct {
+	/**
+	 * The rootUri of the workspace. Is null if no
+	 * folder is open. If both `rootPath` and `rootUri` are set
+	 * `rootUri` wins.
+	 *
+	 * @deprecated in favour of workspaceFolders.
+	 */
+	RootURI DocumentURI `json:"rootUri,omitempty"`
+	/**
+	 * User provided initialization options.
+	 */
+	InitializationOptions json.RawMessage `json:"initializationOptions,omitempty"`
+	/**
+	 * The capabilities provided by the client (editor or tool)
+	 */
+	Capabilities ClientCapabilities `json:"capabilities"`
+	/**
+	 * The actual configured workspace folders.
+	 */
+	WorkspaceFolders []WorkspaceFolder `json:"workspaceFolders,omitempty"`
+}
+
+/**
+ * Defines the capabilities provided by the client, as far as the server uses them.
+ */
+type ClientCapabilities struct {
+	/**
+	 * Workspace specific client capabilities.
+	 */
+	Workspace struct {
+		/**
+		 * Capabilities specific to the `workspace/didChangeWatchedFiles` notification.
+		 */
+		DidChangeWatchedFiles struct {
+			/**
+			 * Did change watched files notification supports dynamic registration.
+			 */
+			DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+		} `json:"didChangeWatchedFiles,omitempty"`
+	} `json:"workspace,omitempty"`
+}
+
+type WorkspaceFolder struct {
+	/**
+	 * The associated URI for this workspace folder.
+	 */
+	URI string `json:"uri"`
+	/**
+	 * The name of the workspace folder. Used to refer to this
+	 * workspace folder in the user interface.
+	 */
+	Name string `json:"name"`
+}
+
+/**
+ * The parameters of a change configuration notification.
+ */
+type DidChangeConfigurationParams struct {
+	/**
+	 * The actual changed settings
+	 */
+	Settings json.RawMessage `json:"settings"`
+}
+
+/**
+ * The watched files change notification's parameters.
+ */
+type DidChangeWatchedFilesParams struct {
+	/**
+	 * The actual file events.
+	 */
+	Changes []FileEvent `json:"changes"`
+}
+
+/**
+ * An event describing a file change.
+ */
+type FileEvent struct {
+	/**
+	 * The file's uri.
+	 */
+	URI DocumentURI `json:"uri"`
+	/**
+	 * The change type.
+	 */
+	Type FileChangeType `json:"type"`
+}
+
+/**
+ * The file event type
+ */
+type FileChangeType float64
+
+/**
+ * General parameters to to register for an capability.
+ */
+type Registration struct {
+	/**
+	 * The id used to register the request. The id can be used to deregister
+	 * the request again.
+	 */
+	ID string `json:"id"`
+	/**
+	 * The method to register for.
+	 */
+	Method string `json:"method"`
+	/**
+	 * Options necessary for the registration.
+	 */
+	RegisterOptions interface{} `json:"registerOptions,omitempty"`
+}
+
+type RegistrationParams struct {
+	Registrations []Registration `json:"registrations"`
+}
+
+/**
+ * Describe options to be used when registered for text document change events.
+ */
+type DidChangeWatchedFilesRegistrationOptions struct {
+	/**
+	 * The watchers to register.
+	 */
+	Watchers []FileSystemWatcher `json:"watchers"`
+}
+
+type FileSystemWatcher struct {
+	/**
+	 * The  glob pattern to watch. Glob patterns can have the following syntax:
+	 * - `*` to match one or more characters in a path segment
+	 * - `?` to match on one character in a path segment
+	 * - `**` to match any number of path segments, including none
+	 * - `{}` to group conditions (e.g. `**​/*.{ts,js}` matches all TypeScript and JavaScript files)
+	 * - `[]` to declare a range of characters to match in a path segment
+	 * - `[!...]` to negate a range of characters to match in a path segment
+	 */
+	GlobPattern string `json:"globPattern"`
+}
+
+type CancelParams struct {
+	/**
+	 * The request id to cancel.
+	 */
+	ID interface{} /*number | string*/ `json:"id"`
+}
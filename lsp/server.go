@@ -5,12 +5,13 @@ package lsp
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
@@ -19,7 +20,6 @@ import (
 
 	Linter "github.com/cremindes/whalelint/linter"
 	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
-	Parser "github.com/cremindes/whalelint/parser"
 	Utils "github.com/cremindes/whalelint/utils"
 )
 
@@ -28,16 +28,6 @@ type (
 	NotificationHandlerMap map[string]func([]byte) (interface{}, error)
 )
 
-var (
-	MethodMap              MethodMapType          // nolint:gochecknoglobals
-	notificationHandlerMap NotificationHandlerMap // nolint:gochecknoglobals
-)
-
-type TextDocumentURIandStageList struct {
-	StageList []instructions.Stage
-	URI       DocumentURI
-}
-
 // Yay is a dummy function for notifications that are not yet supported or we do not care about them.
 func Yay(_ []byte) (interface{}, error) {
 	Log.Println("Yay")
@@ -45,6 +35,7 @@ func Yay(_ []byte) (interface{}, error) {
 	return nil, nil
 }
 
+// OnTextOpen parses the opened document, so it gets stored and its diagnostics get published.
 func OnTextOpen(requestBytes []byte) (interface{}, error) {
 	type TextDocumentWrapper struct {
 		TextDocument TextDocumentItem `json:"textDocument"`
@@ -62,17 +53,22 @@ func OnTextOpen(requestBytes []byte) (interface{}, error) {
 		return nil, fmt.Errorf("failed to unmarshal JSONRPC request OnTextOpen: %w", err)
 	}
 
-	stageList := parseFromText(testDocParam.Params.TextDocument.Text)
+	textDocument := testDocParam.Params.TextDocument
+
+	stageList, metaArgs := parseFromText(textDocument.Text)
 
-	result := TextDocumentURIandStageList{
+	document := Document{
+		URI:       textDocument.URI,
+		Version:   textDocument.Version,
+		Text:      textDocument.Text,
 		StageList: stageList,
-		URI:       testDocParam.Params.TextDocument.URI,
+		MetaArgs:  metaArgs,
 	}
 
-	return result, nil
+	return document, nil
 }
 
-func onTextDocumentDidChange(requestBytes []byte) (interface{}, error) {
+func (session *session) onTextDocumentDidChange(requestBytes []byte) (interface{}, error) {
 	testDocParam := struct {
 		JSONrpcVersion string                      `json:"jsonrpc"`      // "jsonrpc": "2.0",
 		ID             interface{}                 `json:"id,omitempty"` // "id": 1,
@@ -85,14 +81,40 @@ func onTextDocumentDidChange(requestBytes []byte) (interface{}, error) {
 		return nil, fmt.Errorf("failed to unmarshal JSONRPC request OnTextOpen: %w", err)
 	}
 
-	stageList := parseFromText(testDocParam.Params.ContentChanges[0].Text)
+	uri := testDocParam.Params.TextDocument.URI
 
-	result := TextDocumentURIandStageList{
-		StageList: stageList,
-		URI:       testDocParam.Params.TextDocument.URI,
+	// a document, that is not open, can only be changed by full content changes
+	document, _ := session.documents.get(uri)
+
+	// parsing is left to the debounced linting
+	document = Document{
+		URI:       uri,
+		Version:   float64(testDocParam.Params.TextDocument.Version),
+		Text:      ApplyContentChanges(document.Text, testDocParam.Params.ContentChanges),
+		StageList: nil,
+		MetaArgs:  nil,
 	}
 
-	return result, nil
+	session.documents.store(document)
+
+	return ChangedDocument{URI: uri}, nil
+}
+
+// onTextDocumentDidClose returns the closed document, so it gets removed from the store and its diagnostics get
+// cleared.
+func onTextDocumentDidClose(requestBytes []byte) (interface{}, error) {
+	testDocParam := struct {
+		Params struct {
+			TextDocument TextDocumentIdentifier `json:"textDocument"`
+		} `json:"params"`
+	}{}
+
+	err := json.Unmarshal(requestBytes, &testDocParam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSONRPC request didClose: %w", err)
+	}
+
+	return ClosedDocument{URI: testDocParam.Params.TextDocument.URI}, nil
 }
 
 func onTextDocumentDidSave(requestBytes []byte) (interface{}, error) {
@@ -101,10 +123,7 @@ func onTextDocumentDidSave(requestBytes []byte) (interface{}, error) {
 	return "", nil
 }
 
-func parseFromText(str string) []instructions.Stage {
-	// update RawParser
-	Parser.RawParser.UpdateRawStr(str)
-
+func parseFromText(str string) ([]instructions.Stage, []instructions.ArgCommand) {
 	reader := strings.NewReader(str)
 
 	dockerfile, err := parser.Parse(reader)
@@ -112,26 +131,40 @@ func parseFromText(str string) []instructions.Stage {
 		Log.Error("Cannot parse Dockerfile", err)
 	}
 
-	stageList, _ := Utils.ParseDockerfileInstructionsSafely(dockerfile, reader)
+	return Utils.ParseDockerfileInstructionsSafely(dockerfile, reader)
+}
+
+// lintDocument validates the parsed stages of the document against the ruleset, with the config of the workspace.
+func (session *session) lintDocument(document Document) []RuleSet.RuleValidationResult {
+	fileName, _ := pathFromURI(document.URI)
+
+	linter := Linter.Linter{Config: session.getWorkspace().documentConfig(document.URI)}
 
-	return stageList
+	return linter.Run(RuleSet.NewContext(fileName, document.Text).WithMetaArgs(document.MetaArgs), document.StageList)
 }
 
-func PublishDiagnostics(uriAndStageList TextDocumentURIandStageList, w *bufio.Writer) {
+// publishDiagnostics lints the document and publishes the violations for its version. The document is parsed, if it
+// has not been yet.
+func (session *session) publishDiagnostics(document Document) {
+	if document.StageList == nil {
+		document.StageList, document.MetaArgs = parseFromText(document.Text)
+	}
+
 	rr := PublishDiagnosticsParams{
-		URI:         uriAndStageList.URI,
-		Version:     0,
+		URI:         document.URI,
+		Version:     document.Version,
 		Diagnostics: nil,
 	}
 
 	// lint
-	diagList := Linter.MainLinter.Run(uriAndStageList.StageList)
+	diagList := session.lintDocument(document)
 	violationList := filter.Choose(diagList,
 		func(x RuleSet.RuleValidationResult) bool {
 			return x.IsViolated()
 		}).([]RuleSet.RuleValidationResult)
 
 	rr.Diagnostics = make([]Diagnostic, len(violationList))
+	codeActionList := make([]CodeAction, 0)
 
 	for i, diag := range violationList {
 		rr.Diagnostics[i] = Diagnostic{
@@ -145,24 +178,73 @@ func PublishDiagnostics(uriAndStageList TextDocumentURIandStageList, w *bufio.Wr
 			RelatedInformation: nil,
 			Data:               nil,
 		}
+
+		if href, ok := docsReferenceHref(diag.Rule().DocsReference()); ok {
+			rr.Diagnostics[i].CodeDescription = &CodeDescription{Href: href}
+		}
+
+		if codeAction, ok := QuickFixCodeAction(document.URI, document.Text, &violationList[i],
+			rr.Diagnostics[i]); ok {
+			codeActionList = append(codeActionList, codeAction)
+		}
 	}
 
+	session.documents.storeDiagnostics(document, violationList, codeActionList)
+
+	session.publishDiagnosticsParams(rr)
+}
+
+// publishLatestDiagnostics publishes the diagnostics of the latest version of the document of uri, if it's still open.
+func (session *session) publishLatestDiagnostics(uri DocumentURI) {
+	if document, ok := session.documents.get(uri); ok {
+		session.publishDiagnostics(document)
+	}
+}
+
+// clearDiagnostics publishes an empty diagnostic list for the closed document, as clients keep the last one otherwise.
+func (session *session) clearDiagnostics(closedDocument ClosedDocument) {
+	session.publishDiagnosticsParams(PublishDiagnosticsParams{
+		URI:         closedDocument.URI,
+		Version:     0,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+func (session *session) publishDiagnosticsParams(params PublishDiagnosticsParams) {
 	rpcResponse := &RPCNotification{
 		Method: "textDocument/publishDiagnostics",
-		Params: rr,
+		Params: params,
 	}
 
-	err := sendRPCResponse(w, rpcResponse)
+	err := session.send(rpcResponse)
 	if err != nil {
 		Log.Error(err)
 	}
 }
 
-// Initialize gives a response with the server capabilities and info.
-func Initialize(_ interface{}) (interface{}, error) {
+// initialize sets up the workspace and gives a response with the server capabilities and info. Invalid
+// initializationOptions are logged and ignored, so the client can still use the server.
+func (session *session) initialize(params interface{}) (interface{}, error) {
+	initializeParams := InitializeParams{} // nolint:exhaustivestruct
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal initialize params: %w", err)
+	}
+
+	if err := json.Unmarshal(paramsJSON, &initializeParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal initialize params: %w", err)
+	}
+
+	if err := session.initializeWorkspace(initializeParams); err != nil {
+		Log.Error("Invalid initializationOptions | ", err)
+	}
+
 	response := InitializeResult{
 		Capabilities: ServerCapabilities{
-			TextDocumentSync: Full,
+			TextDocumentSync:   Incremental,
+			CodeActionProvider: true,
+			HoverProvider:      true,
 		},
 		ServerInfo: ServerInfo{
 			Name:    "WhaleLintLSP",
@@ -173,20 +255,25 @@ func Initialize(_ interface{}) (interface{}, error) {
 	return response, nil
 }
 
-// Initialized is a handler for client's initialized notification
+// initialized is a handler for client's initialized notification
 //
-// As it does not contain an id, no response is expected.
-func Initialized(_ []byte) (interface{}, error) {
+// As it does not contain an id, no response is expected. The client is asked to watch the config files, if it can.
+func (session *session) initialized(_ []byte) (interface{}, error) {
+	if session.getWorkspace().watchConfigFiles {
+		return RegisterConfigWatcher{}, nil
+	}
+
 	return nil, nil
 }
 
+// Shutdown is a handler for client's shutdown request. It has no result, the session rejects the further requests
+// once it has been answered.
 func Shutdown(_ interface{}) (interface{}, error) {
-	shutdownChannel <- true
-
 	return nil, nil
 }
 
-func sendRPCResponse(w *bufio.Writer, rpcResponse interface{}) error {
+// send sends a response or a notification to the client.
+func (session *session) send(rpcResponse interface{}) error {
 	responseJSON, err := json.Marshal(rpcResponse)
 	if err != nil {
 		return fmt.Errorf("failed to marshal rpcResponse: %w", err)
@@ -199,12 +286,15 @@ func sendRPCResponse(w *bufio.Writer, rpcResponse interface{}) error {
 
 	Log.Debug("Send response to Client: ", string(rawResponse))
 
-	_, err = w.Write(rawResponse)
+	session.writeLock.Lock()
+	defer session.writeLock.Unlock()
+
+	_, err = session.w.Write(rawResponse)
 	if err != nil {
 		return fmt.Errorf("failed to send JSONRPC response: %w", err)
 	}
 
-	err = w.Flush()
+	err = session.w.Flush()
 	if err != nil {
 		return fmt.Errorf("failed to flush JSONRPC connection bufio.Writer: %w", err)
 	}
@@ -212,135 +302,365 @@ func sendRPCResponse(w *bufio.Writer, rpcResponse interface{}) error {
 	return nil
 }
 
-func HandleRequest(w *bufio.Writer, requestBytes []byte) error {
+// ErrExitWithoutShutdown is returned, if the client has sent the exit notification without a shutdown request, so the
+// server is expected to exit with an error.
+var ErrExitWithoutShutdown = errors.New("exit notification without shutdown request")
+
+// errExit signals, that the client has sent the exit notification.
+var errExit = errors.New("exit notification")
+
+// messageQueueSize is the number of messages read ahead, while a request is being handled. This way a
+// $/cancelRequest notification can reach the requests waiting in the queue.
+const messageQueueSize = 64
+
+// session is the state of a single client connection. Each connection has its own documents and workspace, so
+// clients served concurrently do not see each other's.
+type session struct {
+	w *bufio.Writer
+	// writeLock serializes the messages sent to the client, as the debounced diagnostics are published concurrently
+	writeLock  sync.Mutex
+	isShutdown bool
+
+	methodMap              MethodMapType
+	notificationHandlerMap NotificationHandlerMap
+
+	// pendingRequestMap holds the cancelled state of the read, but not yet answered requests per ID
+	pendingRequestMap     map[string]bool
+	pendingRequestMapLock sync.Mutex
+
+	documents *documentStore
+	lints     *lintScheduler
+
+	workspace     workspace
+	workspaceLock sync.Mutex
+}
+
+// nolint:gofmt,gofumpt,goimports
+func newSession(w *bufio.Writer) *session {
+	session := &session{ // nolint:exhaustivestruct
+		w:                 w,
+		isShutdown:        false,
+		pendingRequestMap: map[string]bool{},
+		documents:         newDocumentStore(),
+		workspace:         workspace{}, // nolint:exhaustivestruct
+	}
+
+	session.lints = newLintScheduler(session.publishLatestDiagnostics)
+
+	// the supported request and notification handlers
+	session.methodMap = MethodMapType{
+		"initialize"             : session.initialize,
+		"shutdown"               : Shutdown,
+		"textDocument/codeAction": session.onCodeAction,
+		"textDocument/hover"     : session.onHover,
+	}
+
+	session.notificationHandlerMap = NotificationHandlerMap{
+		"initialized"                     : session.initialized,
+		"textDocument/didOpen"            : OnTextOpen,
+		"textDocument/didClose"           : onTextDocumentDidClose,
+		"textDocument/didChange"          : session.onTextDocumentDidChange,
+		"textDocument/didSave"            : onTextDocumentDidSave,
+		"workspace/didChangeConfiguration": session.onDidChangeConfiguration,
+		"workspace/didChangeWatchedFiles" : OnDidChangeWatchedFiles,
+	}
+
+	return session
+}
+
+// requestKey returns the key of a request ID, which is either a number or a string.
+func requestKey(id interface{}) string {
+	return fmt.Sprintf("%T:%v", id, id)
+}
+
+// readMessages reads the messages into messageC until the input is closed or done is closed. Cancellations are
+// handled right away, so they do not have to wait behind the requests they cancel.
+func (session *session) readMessages(reader *bufio.Reader, messageC chan<- []byte, done <-chan struct{}) error {
+	for {
+		messageBytes, err := ReadMessage(reader)
+		if err != nil {
+			return err
+		}
+
+		message := struct {
+			ID     interface{}  `json:"id"`
+			Method string       `json:"method"`
+			Params CancelParams `json:"params"`
+		}{}
+
+		// invalid messages are answered in order by handleMessage
+		if json.Unmarshal(messageBytes, &message) == nil {
+			if message.ID == nil && message.Method == "$/cancelRequest" {
+				session.cancelRequest(message.Params.ID)
+
+				continue
+			}
+
+			// the responses of the client to the requests of the server are not pending requests
+			if message.ID != nil && message.Method != "" {
+				session.pendingRequestMapLock.Lock()
+				session.pendingRequestMap[requestKey(message.ID)] = false
+				session.pendingRequestMapLock.Unlock()
+			}
+		}
+
+		select {
+		case messageC <- messageBytes:
+		case <-done:
+			return nil
+		}
+	}
+}
+
+// cancelRequest marks the request of id as cancelled, if it has not been answered yet.
+func (session *session) cancelRequest(id interface{}) {
+	session.pendingRequestMapLock.Lock()
+	defer session.pendingRequestMapLock.Unlock()
+
+	if _, ok := session.pendingRequestMap[requestKey(id)]; ok {
+		session.pendingRequestMap[requestKey(id)] = true
+	}
+}
+
+// finishRequest removes the request of id from the pending ones and returns, whether it has been cancelled.
+func (session *session) finishRequest(id interface{}) bool {
+	session.pendingRequestMapLock.Lock()
+	defer session.pendingRequestMapLock.Unlock()
+
+	isCancelled := session.pendingRequestMap[requestKey(id)]
+	delete(session.pendingRequestMap, requestKey(id))
+
+	return isCancelled
+}
+
+// respond sends the response of the request of id, either with its result or with the error object of err.
+func (session *session) respond(id interface{}, result interface{}, err error) error {
+	rpcResponse := &RPCResponse{
+		ID:     id,
+		Result: result,
+		Err:    nil,
+	}
+
+	if err != nil {
+		rpcResponse.Err = responseErrorFrom(err)
+	}
+
+	return session.send(rpcResponse)
+}
+
+// handleMessage handles a request or a notification, while the responses of the client are dropped. Invalid messages
+// and failing requests are answered with an error object, so only a failing connection or the exit notification end
+// the session.
+func (session *session) handleMessage(requestBytes []byte) error {
 	Log.Debug("Client raw request: ", string(requestBytes))
 
+	if !json.Valid(requestBytes) {
+		return session.respond(nil, nil, &ResponseError{Code: ParseError, Message: "invalid JSON", Data: nil})
+	}
+
 	request := RPCRequest{}
 
 	err := json.Unmarshal(requestBytes, &request)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal JSONRPC request : %w", err)
-	}
 
-	if request.ID == nil {
-		// handle notifications
-		// they do no require a response, but may trigger push from server
-		Log.Debug("Received Notification from Client: ", request.Method)
+	// a message with an ID, but without a method is the response of the client to a request of the server, e.g.
+	// client/registerCapability, that needs no answer
+	if err == nil && request.ID != nil && request.Method == "" {
+		Log.Debug("Received Response from Client: ", request.ID)
 
-		handler, ok := notificationHandlerMap[request.Method]
-		if !ok {
-			// unsupported call, that we do not handle at the moment.
-			Log.Debug("Unsupported notification method:", request.Method)
+		return nil
+	}
 
-			return nil
+	if err != nil || request.Method == "" {
+		if request.ID != nil {
+			session.finishRequest(request.ID)
 		}
 
-		r, errNotification := handler(requestBytes)
+		return session.respond(request.ID, nil,
+			&ResponseError{Code: InvalidRequest, Message: "invalid JSONRPC request", Data: nil})
+	}
 
-		// publish r
-		if rr, ok := r.(TextDocumentURIandStageList); ok {
-			PublishDiagnostics(rr, w)
+	if request.ID == nil {
+		session.handleNotification(request.Method, requestBytes)
+
+		if request.Method == "exit" {
+			return errExit
 		}
 
-		return errNotification
+		return nil
 	}
 
 	Log.Debug("Received Request from Client: ", request.Method)
 
-	handler, ok := MethodMap[request.Method]
+	response, errMethod := session.handleRequest(request)
+
+	// the error objects are for the client, the rest are failures of the server
+	var responseError *ResponseError
+	if errMethod != nil && !errors.As(errMethod, &responseError) {
+		Log.Error(request.Method, " | ", errMethod)
+	}
+
+	if session.finishRequest(request.ID) {
+		return session.respond(request.ID, nil,
+			&ResponseError{Code: RequestCancelled, Message: "request cancelled", Data: nil})
+	}
+
+	return session.respond(request.ID, response, errMethod)
+}
+
+// handleRequest returns the result of the request, unless it has been cancelled meanwhile or the server is shut down.
+func (session *session) handleRequest(request RPCRequest) (interface{}, error) {
+	if session.isShutdown {
+		return nil, &ResponseError{Code: InvalidRequest, Message: "server is shut down", Data: nil}
+	}
+
+	if session.finishRequest(request.ID) {
+		return nil, &ResponseError{Code: RequestCancelled, Message: "request cancelled", Data: nil}
+	}
+
+	handler, ok := session.methodMap[request.Method]
+	if !ok {
+		return nil, &ResponseError{Code: MethodNotFound, Message: "unsupported method " + request.Method, Data: nil}
+	}
+
+	// requests about a document get answered based on its latest content
+	if uri, ok := requestDocumentURI(request.Params); ok {
+		session.lints.flush(uri)
+	}
+
+	response, err := handler(request.Params)
+	if err == nil && request.Method == "shutdown" {
+		session.isShutdown = true
+	}
+
+	return response, err
+}
+
+// handleNotification handles a notification. They do no require a response, but may trigger push from server.
+func (session *session) handleNotification(method string, requestBytes []byte) {
+	Log.Debug("Received Notification from Client: ", method)
+
+	// after shutdown only exit is expected
+	if session.isShutdown {
+		return
+	}
+
+	handler, ok := session.notificationHandlerMap[method]
 	if !ok {
 		// unsupported call, that we do not handle at the moment.
-		Log.Debug("Unsupported request method:", request.Method)
+		Log.Debug("Unsupported notification method:", method)
 
-		return nil
+		return
 	}
 
-	response, errMethod := handler(request.Params)
-	if errMethod != nil {
-		Log.Error(errMethod)
+	r, err := handler(requestBytes)
+	if err != nil {
+		Log.Error(method, " | ", err)
 	}
 
-	rpcResponse := &RPCResponse{
-		ID:     request.ID,
-		Result: response,
-		Err:    errMethod,
+	// publish r
+	switch rr := r.(type) {
+	case Document:
+		session.documents.store(rr)
+		session.publishDiagnostics(rr)
+	case ChangedDocument:
+		session.lints.schedule(rr.URI)
+	case ClosedDocument:
+		session.documents.remove(rr.URI)
+		session.lints.cancel(rr.URI)
+		session.clearDiagnostics(rr)
+	case WorkspaceChanged:
+		for _, document := range session.documents.list() {
+			session.publishDiagnostics(document)
+		}
+	case RegisterConfigWatcher:
+		if err := session.send(configWatcherRegistration()); err != nil {
+			Log.Error(err)
+		}
 	}
+}
 
-	return sendRPCResponse(w, rpcResponse)
+// requestDocumentURI returns the URI of the text document, that the request params refer to, if any.
+func requestDocumentURI(params map[string]interface{}) (DocumentURI, bool) {
+	textDocument, ok := params["textDocument"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	uri, ok := textDocument["uri"].(string)
+
+	return DocumentURI(uri), ok
 }
 
-func HandleConnection(connection net.Conn, errC chan error) {
-	log.Printf("Serving %s\n", connection.RemoteAddr().String())
+// HandleConnection serves a client over connection and closes it, once the session has ended.
+func HandleConnection(connection net.Conn) error {
+	defer connection.Close()
 
-	// buff := make([]byte, 5000)
-	c := bufio.NewReader(connection)
-	w := bufio.NewWriter(connection)
+	Log.Info("Serving ", connection.RemoteAddr().String())
 
-	// The base protocol consists of a header and a content part (comparable to HTTP).
-	// The header and content part are separated by a ‘\r\n’.
-	//
-	// Content-Length: ..... \r\n
-	// \r\n
-	// [content part]
+	return HandleStream(connection, connection)
+}
 
-	for {
-		// Content-length: .....
-		// read n byte which contains the message length
-		// read till /r/n
-		contentLengthHeaderBytes, err := c.ReadBytes('\n')
-		if err != nil {
-			errC <- fmt.Errorf("failed to read JSONRPC request header bytes: %w", err)
-		}
+// HandleStream reads JSONRPC messages from reader and writes the responses and notifications to writer, e.g. a TCP
+// connection or stdin and stdout.
+//
+// It returns nil after the exit notification, if the client has shut down the server before, ErrExitWithoutShutdown
+// if not, and an error wrapping io.EOF, if the client has closed the input.
+func HandleStream(reader io.Reader, writer io.Writer) error {
+	w := bufio.NewWriter(writer)
+	session := newSession(w)
 
-		contentLengthHeaderStr := string(contentLengthHeaderBytes[:len(contentLengthHeaderBytes)-2])
+	messageC := make(chan []byte, messageQueueSize)
+	done := make(chan struct{})
 
-		// Skip the next "\r\n".
-		_, err = c.Discard(len("\r\n"))
-		if err != nil {
-			errC <- fmt.Errorf("failed to discard 2 bytes (\r\n) from JSONRPC request : %w", err)
-		}
+	defer close(done)
 
-		// Parse content length.
-		contentLengthStr := strings.TrimPrefix(contentLengthHeaderStr, "Content-Length: ")
-		contentLength, errAtoi := strconv.Atoi(contentLengthStr)
-		if errAtoi != nil { // nolint:wsl
-			errC <- fmt.Errorf("failed to parse content-length from string to int: %w", err)
-		}
+	var readErr error
 
-		requestContentBuff := make([]byte, contentLength)
-		// Read the content.
-		_, err = io.ReadFull(c, requestContentBuff)
-		if err != nil {
-			errC <- fmt.Errorf("failed to read JSONRPC content: %w", err)
-		}
+	go func() {
+		readErr = session.readMessages(bufio.NewReader(reader), messageC, done)
 
-		err = HandleRequest(w, requestContentBuff)
-		if err != nil {
-			errC <- fmt.Errorf("failed to handle JSONRPC request: %w", err)
+		close(messageC)
+	}()
+
+	for messageBytes := range messageC {
+		err := session.handleMessage(messageBytes)
+
+		switch {
+		case errors.Is(err, errExit) && session.isShutdown:
+			return nil
+		case errors.Is(err, errExit):
+			return ErrExitWithoutShutdown
+		case err != nil:
+			return err
 		}
 	}
-}
 
-var shutdownChannel = make(chan bool) // nolint:gochecknoglobals
+	// publish the pending diagnostics, while the client is still listening
+	session.lints.flushAll()
 
-func Serve(port int) error {
-	host := "0.0.0.0"
+	return readErr
+}
 
-	// nolint:gofmt,gofumpt,goimports
-	MethodMap = MethodMapType{
-		"initialize": Initialize,
-		"shutdown"  : Shutdown,
-	}
+// ServeStdio serves a single client over reader and writer, usually stdin and stdout, as most editors expect.
+// It returns, when the client closes the input or sends the exit notification.
+//
+// Note: writer must only be used for the LSP messages, logs go to stderr.
+func ServeStdio(reader io.Reader, writer io.Writer) error {
+	Log.Debug("Serving on stdio")
 
-	// nolint:gofmt,gofumpt,goimports
-	notificationHandlerMap = NotificationHandlerMap{
-		"initialized"           : Initialized,
-		"textDocument/didOpen"  : OnTextOpen,
-		"textDocument/didClose" : Yay,
-		"textDocument/didChange": onTextDocumentDidChange,
-		"textDocument/didSave"  : onTextDocumentDidSave,
+	err := HandleStream(reader, writer)
+	if errors.Is(err, io.EOF) {
+		return nil
 	}
 
+	return err
+}
+
+// Serve serves the clients connecting over TCP on port, until one of them sends the exit notification. A client,
+// that disconnects or breaks the protocol, only ends its own connection.
+func Serve(port int) error {
+	host := "0.0.0.0"
+
 	serviceAddress := host + ":" + strconv.Itoa(port)
 
 	tcpAddress, errResolveTCP := net.ResolveTCPAddr("tcp", serviceAddress)
@@ -356,31 +676,40 @@ func Serve(port int) error {
 	defer listener.Close()
 
 	Log.Println("Listening on", tcpAddress)
-	// c1 := make(chan string)
-
-	errorChannel := make(chan error)
-	shutdown := false
 
-	// Serve
-	for !shutdown {
-		connection, err := listener.Accept()
-		if err != nil {
-			return fmt.Errorf("LSP | cannot accept connection %w", err)
+	exitC := make(chan error, 1)
+
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				sendError(exitC, fmt.Errorf("LSP | cannot accept connection %w", err))
+
+				return
+			}
+
+			go func() {
+				err := HandleConnection(connection)
+
+				switch {
+				case err == nil || errors.Is(err, ErrExitWithoutShutdown):
+					sendError(exitC, err)
+				case errors.Is(err, io.EOF):
+					Log.Info("Client disconnected ", connection.RemoteAddr().String())
+				default:
+					Log.Error(err)
+				}
+			}()
 		}
+	}()
 
-		go HandleConnection(connection, errorChannel)
-
-		select {
-		case err := <-errorChannel:
-			Log.Error(err)
+	return <-exitC
+}
 
-			break
-		case shutdown = <-shutdownChannel:
-			continue
-		default:
-			continue
-		}
+// sendError sends err to errC, unless an earlier error is already waiting there.
+func sendError(errC chan<- error, err error) {
+	select {
+	case errC <- err:
+	default:
 	}
-
-	return nil
 }
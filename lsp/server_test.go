@@ -1,9 +1,17 @@
 package lsp_test
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
@@ -22,12 +30,15 @@ func TestOnTextOpen(t *testing.T) {
 	dockerfile, parseErr := parser.Parse(reader)
 	assert.Nil(t, parseErr)
 
-	stageList, _, paerseStageErr := instructions.Parse(dockerfile.AST)
+	stageList, metaArgs, paerseStageErr := instructions.Parse(dockerfile.AST)
 	assert.Nil(t, paerseStageErr)
 
-	expected := LSP.TextDocumentURIandStageList{
-		StageList: stageList,
+	expected := LSP.Document{
 		URI:       "mockURI",
+		Version:   3,
+		Text:      str,
+		StageList: stageList,
+		MetaArgs:  metaArgs,
 	}
 
 	type TextDocumentWrapper struct {
@@ -46,7 +57,7 @@ func TestOnTextOpen(t *testing.T) {
 		TextDocumentWrapper{LSP.TextDocumentItem{ // nolint:exhaustivestruct
 			URI:        "mockURI",
 			LanguageID: "dockerfile",
-			Version:    0,
+			Version:    3,
 			Text:       str,
 		}},
 	}
@@ -59,3 +70,568 @@ func TestOnTextOpen(t *testing.T) {
 	assert.Equal(t, expected, result)
 	assert.Nil(t, err)
 }
+
+func lspMessage(t *testing.T, message interface{}) string {
+	t.Helper()
+
+	content, err := json.Marshal(message)
+	assert.Nil(t, err)
+
+	return "Content-Length: " + strconv.Itoa(len(content)) + "\r\n\r\n" + string(content)
+}
+
+// lspOutputMessages splits the output of the server into the JSON messages.
+func lspOutputMessages(t *testing.T, output string) []string {
+	t.Helper()
+
+	messageList := make([]string, 0)
+
+	for output != "" {
+		header := strings.SplitN(output, "\r\n\r\n", 2) // nolint:gomnd
+		assert.Len(t, header, 2)
+
+		contentLength, err := strconv.Atoi(strings.TrimPrefix(header[0], "Content-Length: "))
+		assert.Nil(t, err)
+
+		messageList = append(messageList, header[1][:contentLength])
+		output = header[1][contentLength:]
+	}
+
+	return messageList
+}
+
+// nolint:paralleltest
+func TestServeStdio(t *testing.T) {
+	input := lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]interface{}{},
+	}) + lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": "file:///Dockerfile", "languageId": "dockerfile", "version": 1, "text": "FROM golang",
+			},
+		},
+	})
+	output := &strings.Builder{}
+
+	// returns on EOF
+	assert.Nil(t, LSP.ServeStdio(strings.NewReader(input), output))
+
+	assert.Contains(t, output.String(), `"name":"WhaleLintLSP"`)
+	assert.Contains(t, output.String(), `"method":"textDocument/publishDiagnostics"`)
+	assert.Contains(t, output.String(), `"code":"STS001"`)
+}
+
+// nolint:paralleltest
+func TestServeStdio_CodeAction(t *testing.T) {
+	uri := "file:///code-action/Dockerfile"
+	input := lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": uri, "languageId": "dockerfile", "version": 1,
+				"text": "FROM ubuntu:20.04\nMAINTAINER John Doe",
+			},
+		},
+	}) + lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "id": 2, "method": "textDocument/codeAction", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri},
+			"range": map[string]interface{}{
+				"start": map[string]interface{}{"line": 1, "character": 0},
+				"end":   map[string]interface{}{"line": 1, "character": 0},
+			},
+			"context": map[string]interface{}{"diagnostics": []interface{}{}},
+		},
+	})
+	output := &strings.Builder{}
+
+	assert.Nil(t, LSP.ServeStdio(strings.NewReader(input), output))
+
+	assert.Contains(t, output.String(), `"kind":"quickfix"`)
+	assert.Contains(t, output.String(), `"newText":"LABEL maintainer=\"John Doe\""`)
+}
+
+// nolint:paralleltest
+func TestServeStdio_Hover(t *testing.T) {
+	uri := "file:///hover/Dockerfile"
+	input := lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": uri, "languageId": "dockerfile", "version": 1, "text": "FROM golang\nWORKDIR app",
+			},
+		},
+	}) + lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "id": 2, "method": "textDocument/hover", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri},
+			"position":     map[string]interface{}{"line": 1, "character": 2},
+		},
+	})
+	output := &strings.Builder{}
+
+	assert.Nil(t, LSP.ServeStdio(strings.NewReader(input), output))
+
+	// diagnostics link to the rule reference
+	assert.Contains(t, output.String(),
+		`"codeDescription":{"href":"https://docs.docker.com/engine/reference/builder/#workdir"}`)
+	// hover shows the violated rule and the instruction
+	assert.Contains(t, output.String(), `"kind":"markdown"`)
+	assert.Contains(t, output.String(), `**WKD001** | Warning`)
+	assert.Contains(t, output.String(), `**WORKDIR**`)
+}
+
+// nolint:paralleltest,funlen
+func TestServeStdio_MultipleDocuments(t *testing.T) {
+	uriA, uriB := "file:///a/Dockerfile", "file:///b/Dockerfile"
+	didOpen := func(uri string, version int, text string) string {
+		return lspMessage(t, map[string]interface{}{
+			"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]interface{}{
+				"textDocument": map[string]interface{}{
+					"uri": uri, "languageId": "dockerfile", "version": version, "text": text,
+				},
+			},
+		})
+	}
+	hover := func(id int, uri string) string {
+		return lspMessage(t, map[string]interface{}{
+			"jsonrpc": "2.0", "id": id, "method": "textDocument/hover", "params": map[string]interface{}{
+				"textDocument": map[string]interface{}{"uri": uri},
+				"position":     map[string]interface{}{"line": 1, "character": 0},
+			},
+		})
+	}
+	input := didOpen(uriA, 3, "FROM golang:1.16\nWORKDIR app") +
+		didOpen(uriB, 7, "FROM golang:1.16\nMAINTAINER John Doe") +
+		lspMessage(t, map[string]interface{}{
+			"jsonrpc": "2.0", "method": "textDocument/didChange", "params": map[string]interface{}{
+				"textDocument":   map[string]interface{}{"uri": uriA, "version": 4},
+				"contentChanges": []interface{}{map[string]interface{}{"text": "FROM golang:1.16\nworkdir src"}},
+			},
+		}) +
+		hover(1, uriA) + hover(2, uriB) +
+		lspMessage(t, map[string]interface{}{
+			"jsonrpc": "2.0", "method": "textDocument/didClose", "params": map[string]interface{}{
+				"textDocument": map[string]interface{}{"uri": uriB},
+			},
+		}) +
+		hover(3, uriB)
+	output := &strings.Builder{}
+
+	assert.Nil(t, LSP.ServeStdio(strings.NewReader(input), output))
+
+	messageList := lspOutputMessages(t, output.String())
+	assert.Len(t, messageList, 7)
+
+	// diagnostics are published for the version of each document
+	assert.Contains(t, messageList[0], `"uri":"file:///a/Dockerfile","version":3`)
+	assert.Contains(t, messageList[0], `"code":"WKD001"`)
+	assert.Contains(t, messageList[1], `"uri":"file:///b/Dockerfile","version":7`)
+	assert.Contains(t, messageList[1], `"code":"MTR001"`)
+	assert.NotContains(t, messageList[1], `"code":"WKD001"`)
+	assert.Contains(t, messageList[2], `"uri":"file:///a/Dockerfile","version":4`)
+	assert.Contains(t, messageList[2], `"start":{"line":1,"character":8}`)
+
+	// the documents don't clobber each other
+	assert.Contains(t, messageList[3], `**WKD001**`)
+	assert.NotContains(t, messageList[3], `**MTR001**`)
+	assert.Contains(t, messageList[4], `**MTR001**`)
+	assert.NotContains(t, messageList[4], `**WKD001**`)
+
+	// closing clears the diagnostics
+	assert.Equal(t, `{"method":"textDocument/publishDiagnostics","params":{"uri":"file:///b/Dockerfile",`+
+		`"diagnostics":[]}}`, messageList[5])
+	assert.Contains(t, messageList[6], `"id":3,"result":null`)
+}
+
+// nolint:paralleltest
+func TestServeStdio_IncrementalChanges(t *testing.T) {
+	uri := "file:///incremental/Dockerfile"
+	didChange := func(version int, line, character float64, text string) string {
+		return lspMessage(t, map[string]interface{}{
+			"jsonrpc": "2.0", "method": "textDocument/didChange", "params": map[string]interface{}{
+				"textDocument": map[string]interface{}{"uri": uri, "version": version},
+				"contentChanges": []interface{}{map[string]interface{}{
+					"range": map[string]interface{}{
+						"start": map[string]interface{}{"line": line, "character": character},
+						"end":   map[string]interface{}{"line": line, "character": character},
+					},
+					"text": text,
+				}},
+			},
+		})
+	}
+	input := lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]interface{}{},
+	}) + lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": uri, "languageId": "dockerfile", "version": 1, "text": "FROM golang\nWORKDIR ",
+			},
+		},
+	}) + didChange(2, 1, 8, "a") + didChange(3, 1, 9, "p") + didChange(4, 1, 10, "p")
+	output := &strings.Builder{}
+
+	assert.Nil(t, LSP.ServeStdio(strings.NewReader(input), output))
+
+	messageList := lspOutputMessages(t, output.String())
+
+	// the typing is linted once, after the last change
+	assert.Len(t, messageList, 3)
+	assert.Contains(t, messageList[0], `"textDocumentSync":2`)
+	assert.Contains(t, messageList[1], `"version":1`)
+	assert.Contains(t, messageList[2], `"version":4`)
+	assert.Contains(t, messageList[2], `"code":"WKD001"`)
+	assert.Contains(t, messageList[2], `"range":{"start":{"line":1,"character":8},"end":{"line":1,"character":11}}`)
+}
+
+// nolint:paralleltest,funlen
+func TestServeStdio_Workspace(t *testing.T) {
+	rootDir := t.TempDir()
+	dockerfilePath := filepath.Join(rootDir, "svc", "Dockerfile")
+	rootURI := "file://" + filepath.ToSlash(rootDir)
+	uri := rootURI + "/svc/Dockerfile"
+
+	// STS001 and RUN004
+	assert.Nil(t, os.MkdirAll(filepath.Join(rootDir, "ci"), 0o700))
+	assert.Nil(t, os.MkdirAll(filepath.Dir(dockerfilePath), 0o700))
+	assert.Nil(t, os.WriteFile(dockerfilePath, []byte("FROM golang\nRUN sudo ls"), 0o600))
+	assert.Nil(t, os.WriteFile(filepath.Join(rootDir, "svc", ".whalelint.yml"),
+		[]byte("rules:\n  RUN004:\n    severity: Error\n"), 0o600))
+	assert.Nil(t, os.WriteFile(filepath.Join(rootDir, "ci", ".whalelint.yml"), []byte("disable: [RUN004]\n"), 0o600))
+
+	didChangeConfiguration := func(settings interface{}) string {
+		return lspMessage(t, map[string]interface{}{
+			"jsonrpc": "2.0", "method": "workspace/didChangeConfiguration",
+			"params": map[string]interface{}{"settings": settings},
+		})
+	}
+	didChangeWatchedFiles := func(fileURI string) string {
+		return lspMessage(t, map[string]interface{}{
+			"jsonrpc": "2.0", "method": "workspace/didChangeWatchedFiles", "params": map[string]interface{}{
+				"changes": []interface{}{map[string]interface{}{"uri": fileURI, "type": 2}},
+			},
+		})
+	}
+	input := lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]interface{}{
+			"workspaceFolders":      []interface{}{map[string]interface{}{"uri": rootURI, "name": "root"}},
+			"initializationOptions": map[string]interface{}{"disable": []string{"STS001"}},
+			"capabilities": map[string]interface{}{
+				"workspace": map[string]interface{}{
+					"didChangeWatchedFiles": map[string]interface{}{"dynamicRegistration": true},
+				},
+			},
+		},
+	}) + lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "method": "initialized", "params": map[string]interface{}{},
+	}) + lspMessage(t, map[string]interface{}{
+		"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": uri, "languageId": "dockerfile", "version": 1, "text": "FROM golang\nRUN sudo ls",
+			},
+		},
+	}) +
+		didChangeConfiguration(map[string]interface{}{
+			"whalelint": map[string]interface{}{"configPath": "ci/.whalelint.yml"},
+		}) +
+		didChangeWatchedFiles(rootURI+"/svc/.whalelint.yml") +
+		didChangeWatchedFiles(rootURI+"/svc/Dockerfile") +
+		didChangeConfiguration(nil)
+	output := &strings.Builder{}
+
+	assert.Nil(t, LSP.ServeStdio(strings.NewReader(input), output))
+
+	messageList := lspOutputMessages(t, output.String())
+	assert.Len(t, messageList, 6)
+
+	// the client is asked to watch the config files
+	assert.Contains(t, messageList[1], `"method":"client/registerCapability"`)
+	assert.Contains(t, messageList[1], `"globPattern":"**/{.whalelint.yml,.whalelint.yaml,.whalelint.json}"`)
+
+	// the discovered config file and the initializationOptions
+	assert.NotContains(t, messageList[2], `"code":"STS001"`)
+	assert.Contains(t, messageList[2], `"severity":1,"code":"RUN004"`)
+
+	// the config file of the settings relative to the workspace folder, without the earlier settings
+	assert.Contains(t, messageList[3], `"code":"STS001"`)
+	assert.NotContains(t, messageList[3], `"code":"RUN004"`)
+
+	// config file change
+	assert.Equal(t, messageList[3], messageList[4])
+
+	// no settings
+	assert.Contains(t, messageList[5], `"code":"STS001"`)
+	assert.Contains(t, messageList[5], `"severity":1,"code":"RUN004"`)
+}
+
+// lspClient drives a server session through net.Pipe, as an editor would through a connection.
+type lspClient struct {
+	t          *testing.T
+	connection net.Conn
+	reader     *bufio.Reader
+	errC       chan error
+}
+
+func newLSPClient(t *testing.T) *lspClient {
+	t.Helper()
+
+	serverConnection, clientConnection := net.Pipe()
+	client := &lspClient{
+		t:          t,
+		connection: clientConnection,
+		reader:     bufio.NewReader(clientConnection),
+		errC:       make(chan error, 1),
+	}
+
+	go func() {
+		client.errC <- LSP.HandleConnection(serverConnection)
+	}()
+
+	t.Cleanup(func() { clientConnection.Close() })
+
+	return client
+}
+
+// sendRaw sends the content with a Content-Length header.
+func (client *lspClient) sendRaw(content string) {
+	client.t.Helper()
+
+	assert.Nil(client.t, client.connection.SetWriteDeadline(time.Now().Add(5*time.Second)))
+
+	_, err := client.connection.Write([]byte("Content-Length: " + strconv.Itoa(len(content)) + "\r\n\r\n" + content))
+	assert.Nil(client.t, err)
+}
+
+func (client *lspClient) send(message map[string]interface{}) {
+	client.t.Helper()
+
+	message["jsonrpc"] = "2.0"
+
+	content, err := json.Marshal(message)
+	assert.Nil(client.t, err)
+
+	client.sendRaw(string(content))
+}
+
+// receive returns the next message of the server.
+func (client *lspClient) receive() map[string]interface{} {
+	client.t.Helper()
+
+	assert.Nil(client.t, client.connection.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	content, err := LSP.ReadMessage(client.reader)
+	assert.Nil(client.t, err)
+
+	message := map[string]interface{}{}
+	assert.Nil(client.t, json.Unmarshal(content, &message))
+
+	return message
+}
+
+// receiveErrorCode returns the error code of the next response and asserts, that it answers the request of id.
+func (client *lspClient) receiveErrorCode(id interface{}) float64 {
+	client.t.Helper()
+
+	message := client.receive()
+	assert.Equal(client.t, id, message["id"])
+	assert.NotContains(client.t, message, "result")
+
+	responseError, ok := message["error"].(map[string]interface{})
+	assert.True(client.t, ok, message)
+
+	code, _ := responseError["code"].(float64)
+
+	return code
+}
+
+// sessionErr returns the error, that the session has ended with.
+func (client *lspClient) sessionErr() error {
+	client.t.Helper()
+
+	select {
+	case err := <-client.errC:
+		return err
+	case <-time.After(5 * time.Second):
+		client.t.Fatal("the session has not ended")
+
+		return nil
+	}
+}
+
+// nolint:paralleltest
+func TestHandleConnection_Lifecycle(t *testing.T) {
+	client := newLSPClient(t)
+
+	client.send(map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}})
+	assert.Contains(t, client.receive()["result"], "capabilities")
+
+	client.send(map[string]interface{}{"id": 2, "method": "textDocument/definition", "params": map[string]interface{}{}})
+	assert.Equal(t, float64(LSP.MethodNotFound), client.receiveErrorCode(float64(2)))
+
+	client.send(map[string]interface{}{"id": "shutdown", "method": "shutdown"})
+
+	shutdownResponse := client.receive()
+	assert.Equal(t, "shutdown", shutdownResponse["id"])
+	assert.Contains(t, shutdownResponse, "result")
+	assert.Nil(t, shutdownResponse["result"])
+
+	// after shutdown, requests are rejected and notifications are ignored
+	client.send(map[string]interface{}{"id": 3, "method": "textDocument/hover", "params": map[string]interface{}{}})
+	assert.Equal(t, float64(LSP.InvalidRequest), client.receiveErrorCode(float64(3)))
+
+	client.send(map[string]interface{}{"method": "textDocument/didOpen", "params": map[string]interface{}{
+		"textDocument": map[string]interface{}{
+			"uri": "file:///shutdown/Dockerfile", "languageId": "dockerfile", "version": 1, "text": "FROM golang",
+		},
+	}})
+	client.send(map[string]interface{}{"method": "exit"})
+
+	assert.Nil(t, client.sessionErr())
+
+	// the connection is closed, without anything published
+	_, err := client.reader.ReadByte()
+	assert.True(t, errors.Is(err, io.EOF), err)
+}
+
+// nolint:paralleltest
+func TestHandleConnection_ExitWithoutShutdown(t *testing.T) {
+	client := newLSPClient(t)
+
+	client.send(map[string]interface{}{"method": "exit"})
+
+	assert.True(t, errors.Is(client.sessionErr(), LSP.ErrExitWithoutShutdown))
+}
+
+// nolint:paralleltest
+func TestHandleConnection_InvalidMessages(t *testing.T) {
+	client := newLSPClient(t)
+
+	client.sendRaw(`{"jsonrpc": "2.0", "id": 1, "method": `)
+	assert.Equal(t, float64(LSP.ParseError), client.receiveErrorCode(nil))
+
+	// a message without a method, but with an ID is a response, so it's not answered
+	client.sendRaw(`{"jsonrpc": "2.0", "id": 2}`)
+
+	client.sendRaw(`{"jsonrpc": "2.0"}`)
+	assert.Equal(t, float64(LSP.InvalidRequest), client.receiveErrorCode(nil))
+
+	client.sendRaw(`{"jsonrpc": "2.0", "id": 3, "method": "initialize", "params": []}`)
+	assert.Equal(t, float64(LSP.InvalidRequest), client.receiveErrorCode(float64(3)))
+
+	// the session goes on, whatever the headers are
+	content := `{"jsonrpc":"2.0","id":4,"method":"initialize","params":{}}`
+	_, err := client.connection.Write([]byte("Content-Type: application/vscode-jsonrpc; charset=utf-8\r\n" +
+		"content-length:" + strconv.Itoa(len(content)) + "\r\n\r\n" + content))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(4), client.receive()["id"])
+
+	// a malformed header ends the session, as the next message cannot be found
+	_, err = client.connection.Write([]byte("Content-Length: two\r\n\r\n{}"))
+	assert.Nil(t, err)
+	assert.True(t, errors.Is(client.sessionErr(), LSP.ErrInvalidHeader))
+}
+
+// nolint:paralleltest
+func TestHandleConnection_CancelRequest(t *testing.T) {
+	client := newLSPClient(t)
+
+	// the session is stuck writing the response of the first request, until it's received
+	client.send(map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}})
+	client.send(map[string]interface{}{"id": 2, "method": "textDocument/hover", "params": map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": "file:///cancel/Dockerfile"},
+		"position":     map[string]interface{}{"line": 0, "character": 0},
+	}})
+	client.send(map[string]interface{}{"method": "$/cancelRequest", "params": map[string]interface{}{"id": 2}})
+	// cancelling an unknown request has no effect
+	client.send(map[string]interface{}{"method": "$/cancelRequest", "params": map[string]interface{}{"id": 42}})
+
+	assert.Equal(t, float64(1), client.receive()["id"])
+	assert.Equal(t, float64(LSP.RequestCancelled), client.receiveErrorCode(float64(2)))
+
+	client.send(map[string]interface{}{"id": 42, "method": "shutdown"})
+
+	shutdownResponse := client.receive()
+	assert.Equal(t, float64(42), shutdownResponse["id"])
+	assert.NotContains(t, shutdownResponse, "error")
+
+	client.send(map[string]interface{}{"method": "exit"})
+	assert.Nil(t, client.sessionErr())
+}
+
+func TestHandleConnection_SeparateSessions(t *testing.T) {
+	t.Parallel()
+
+	uri := "file:///sessions/Dockerfile"
+	clientA, clientB := newLSPClient(t), newLSPClient(t)
+
+	clientA.send(map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{
+		"initializationOptions": map[string]interface{}{"disable": []string{"STS001"}},
+	}})
+	clientB.send(map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}})
+
+	assert.Equal(t, float64(1), clientA.receive()["id"])
+	assert.Equal(t, float64(1), clientB.receive()["id"])
+
+	// the same document is linted with the settings of each client
+	for _, client := range []*lspClient{clientA, clientB} {
+		client.send(map[string]interface{}{"method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": uri, "languageId": "dockerfile", "version": 1, "text": "FROM golang\nWORKDIR app",
+			},
+		}})
+	}
+
+	diagnosticsA, err := json.Marshal(clientA.receive())
+	assert.Nil(t, err)
+	assert.NotContains(t, string(diagnosticsA), `"code":"STS001"`)
+	assert.Contains(t, string(diagnosticsA), `"code":"WKD001"`)
+
+	diagnosticsB, err := json.Marshal(clientB.receive())
+	assert.Nil(t, err)
+	assert.Contains(t, string(diagnosticsB), `"code":"STS001"`)
+
+	// closing the document on one connection leaves it open on the other one
+	clientA.send(map[string]interface{}{"method": "textDocument/didClose", "params": map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+	}})
+	clientA.receive()
+
+	clientB.send(map[string]interface{}{"id": 2, "method": "textDocument/hover", "params": map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"position":     map[string]interface{}{"line": 1, "character": 0},
+	}})
+
+	hover, err := json.Marshal(clientB.receive())
+	assert.Nil(t, err)
+	assert.Contains(t, string(hover), `**WKD001**`)
+}
+
+// nolint:paralleltest
+func TestHandleConnection_ClientResponse(t *testing.T) {
+	client := newLSPClient(t)
+
+	client.send(map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"workspace": map[string]interface{}{
+				"didChangeWatchedFiles": map[string]interface{}{"dynamicRegistration": true},
+			},
+		},
+	}})
+	assert.Equal(t, float64(1), client.receive()["id"])
+
+	client.send(map[string]interface{}{"method": "initialized", "params": map[string]interface{}{}})
+
+	registration := client.receive()
+	assert.Equal(t, "client/registerCapability", registration["method"])
+
+	// the responses of the client are not answered
+	client.send(map[string]interface{}{"id": registration["id"], "result": nil})
+	client.send(map[string]interface{}{"id": registration["id"], "error": map[string]interface{}{
+		"code": LSP.InvalidRequest, "message": "unsupported",
+	}})
+	client.send(map[string]interface{}{"id": 2, "method": "shutdown"})
+
+	shutdownResponse := client.receive()
+	assert.Equal(t, float64(2), shutdownResponse["id"])
+	assert.NotContains(t, shutdownResponse, "error")
+
+	client.send(map[string]interface{}{"method": "exit"})
+	assert.Nil(t, client.sessionErr())
+}
@@ -0,0 +1,71 @@
+package lsp
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ApplyContentChanges applies the changes of a textDocument/didChange notification to text in order. A change without
+// a range replaces the whole text, otherwise only the range, whose characters are counted in UTF-16 code units.
+func ApplyContentChanges(text string, changeList []TextDocumentContentChangeEvent) string {
+	for _, change := range changeList {
+		if change.Range == nil {
+			text = change.Text
+
+			continue
+		}
+
+		start := ByteOffsetFromPosition(text, change.Range.Start)
+		end := ByteOffsetFromPosition(text, change.Range.End)
+
+		if end < start {
+			start, end = end, start
+		}
+
+		text = text[:start] + change.Text + text[end:]
+	}
+
+	return text
+}
+
+// ByteOffsetFromPosition returns the byte offset of position in text. Positions beyond the end of a line or of the
+// text are clamped to the end of it, as the protocol requires.
+func ByteOffsetFromPosition(text string, position Position) int {
+	lineStart := 0
+
+	for line := 0; line < int(position.Line); line++ {
+		newLineIndex := strings.IndexByte(text[lineStart:], '\n')
+		if newLineIndex == -1 {
+			return len(text)
+		}
+
+		lineStart += newLineIndex + 1
+	}
+
+	lineEnd := len(text)
+	if newLineIndex := strings.IndexByte(text[lineStart:], '\n'); newLineIndex != -1 {
+		lineEnd = lineStart + newLineIndex
+	}
+
+	// the line terminator is "\r\n"
+	lineEnd = lineStart + len(strings.TrimSuffix(text[lineStart:lineEnd], "\r"))
+
+	offset := lineStart
+
+	for character := 0; offset < lineEnd && character < int(position.Character); {
+		char, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+		character += utf16Len(char)
+	}
+
+	return offset
+}
+
+// utf16Len returns the number of UTF-16 code units encoding char.
+func utf16Len(char rune) int {
+	if char >= 0x10000 { // nolint:gomnd // outside of the Basic Multilingual Plane, i.e. a surrogate pair
+		return 2 // nolint:gomnd
+	}
+
+	return 1
+}
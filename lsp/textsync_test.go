@@ -0,0 +1,77 @@
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	LSP "github.com/cremindes/whalelint/lsp"
+)
+
+func newRange(startLine, startChar, endLine, endChar float64) *LSP.Range {
+	return &LSP.Range{
+		Start: LSP.Position{Line: startLine, Character: startChar},
+		End:   LSP.Position{Line: endLine, Character: endChar},
+	}
+}
+
+func TestApplyContentChanges(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		Text         string
+		ChangeList   []LSP.TextDocumentContentChangeEvent
+		ExpectedText string
+	}{
+		{
+			Name:         "Full content change.",
+			Text:         "FROM golang",
+			ChangeList:   []LSP.TextDocumentContentChangeEvent{{Text: "FROM alpine"}},
+			ExpectedText: "FROM alpine",
+		},
+		{
+			Name: "Insertion and deletion in order.",
+			Text: "FROM golang\nRUN date\n",
+			ChangeList: []LSP.TextDocumentContentChangeEvent{
+				{Range: newRange(0, 11, 0, 11), Text: ":1.16"},
+				{Range: newRange(1, 0, 2, 0), Text: ""},
+			},
+			ExpectedText: "FROM golang:1.16\n",
+		},
+		{
+			Name: "Characters outside of the BMP count as two.",
+			Text: "LABEL owner=\"🐳 Moby\"\nCMD date",
+			ChangeList: []LSP.TextDocumentContentChangeEvent{
+				{Range: newRange(0, 16, 0, 20), Text: "Docker"},
+			},
+			ExpectedText: "LABEL owner=\"🐳 Docker\"\nCMD date",
+		},
+		{
+			Name: "Multi-line replacement with CRLF line endings.",
+			Text: "FROM golang\r\nRUN apt-get update && \\\r\n    apt-get install vim\r\n",
+			ChangeList: []LSP.TextDocumentContentChangeEvent{
+				{Range: newRange(1, 22, 2, 99), Text: "apt-get install -y vim"},
+			},
+			ExpectedText: "FROM golang\r\nRUN apt-get update && apt-get install -y vim\r\n",
+		},
+		{
+			Name: "Positions beyond the text are clamped.",
+			Text: "FROM golang",
+			ChangeList: []LSP.TextDocumentContentChangeEvent{
+				{Range: newRange(5, 0, 6, 0), Text: "\nCMD date"},
+			},
+			ExpectedText: "FROM golang\nCMD date",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.ExpectedText, LSP.ApplyContentChanges(testCase.Text, testCase.ChangeList))
+		})
+	}
+}
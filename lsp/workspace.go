@@ -0,0 +1,242 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	Log "github.com/sirupsen/logrus"
+
+	Config "github.com/cremindes/whalelint/config"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// Settings are the editor settings of WhaleLint, passed as initializationOptions or by
+// workspace/didChangeConfiguration, optionally under a "whalelint" section. Besides the config file path, they have the
+// structure of the config file, e.g.
+//
+//	{"configPath": "build/.whalelint.yml", "disable": ["RUN002"], "rules": {"RUN009": {"severity": "Error"}}}
+//
+// A relative config file path is resolved against the workspace folder of the document. Without one, the config file
+// is auto-discovered next to the Dockerfile, as in the CLI. Either way, the rule settings override the config file.
+type Settings struct {
+	ConfigPath string
+	Config     *Config.Config
+}
+
+// ParseSettings parses and validates the settings from their JSON representation.
+func ParseSettings(settingsJSON []byte) (Settings, error) {
+	section := struct {
+		WhaleLint json.RawMessage `json:"whalelint"`
+	}{}
+
+	// the settings object may be null, or not even an object
+	if err := json.Unmarshal(settingsJSON, &section); err == nil && len(section.WhaleLint) > 0 {
+		settingsJSON = section.WhaleLint
+	}
+
+	configPath := struct {
+		ConfigPath string `json:"configPath"`
+	}{}
+
+	if err := json.Unmarshal(settingsJSON, &configPath); err != nil {
+		return Settings{}, fmt.Errorf("settings | %w", err)
+	}
+
+	// JSON is a subset of YAML, so the settings can be parsed as a config file
+	config, err := Config.Parse(string(settingsJSON))
+	if err != nil {
+		return Settings{}, fmt.Errorf("settings | %w", err)
+	}
+
+	return Settings{ConfigPath: configPath.ConfigPath, Config: config}, nil
+}
+
+// WorkspaceChanged signals, that the settings or a config file has changed, so the diagnostics of every open document
+// are re-published.
+type WorkspaceChanged struct{}
+
+// RegisterConfigWatcher signals, that the client is to be asked to watch the config files.
+type RegisterConfigWatcher struct{}
+
+// configWatcherRegistration asks the client to send workspace/didChangeWatchedFiles notifications about the config
+// files.
+func configWatcherRegistration() RPCRequest {
+	return RPCRequest{
+		JSONrpcVersion: "2.0",
+		ID:             "whalelint/watchConfigFiles",
+		Method:         "client/registerCapability",
+		Params: map[string]interface{}{
+			"registrations": []Registration{{
+				ID:     "whalelint/watchConfigFiles",
+				Method: "workspace/didChangeWatchedFiles",
+				RegisterOptions: DidChangeWatchedFilesRegistrationOptions{
+					Watchers: []FileSystemWatcher{{GlobPattern: "**/{" + strings.Join(Config.FileNameList, ",") + "}"}},
+				},
+			}},
+		},
+	}
+}
+
+// onDidChangeConfiguration updates the settings of the workspace of the session.
+func (session *session) onDidChangeConfiguration(requestBytes []byte) (interface{}, error) {
+	request := struct {
+		Params DidChangeConfigurationParams `json:"params"`
+	}{}
+
+	if err := json.Unmarshal(requestBytes, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSONRPC request didChangeConfiguration: %w", err)
+	}
+
+	settings, err := ParseSettings(request.Params.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	session.updateSettings(settings)
+
+	return WorkspaceChanged{}, nil
+}
+
+// OnDidChangeWatchedFiles re-publishes the diagnostics, if any of the config files has changed.
+func OnDidChangeWatchedFiles(requestBytes []byte) (interface{}, error) {
+	request := struct {
+		Params DidChangeWatchedFilesParams `json:"params"`
+	}{}
+
+	if err := json.Unmarshal(requestBytes, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSONRPC request didChangeWatchedFiles: %w", err)
+	}
+
+	for _, fileEvent := range request.Params.Changes {
+		if isConfigFile(fileEvent.URI) {
+			return WorkspaceChanged{}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// workspace is the state of the client's workspace, that the linting depends on.
+type workspace struct {
+	folderList       []string
+	settings         Settings
+	watchConfigFiles bool
+}
+
+// initializeWorkspace sets up the workspace of the session from the initialize request parameters.
+func (session *session) initializeWorkspace(params InitializeParams) error {
+	folderList := make([]string, 0, len(params.WorkspaceFolders))
+
+	for _, folder := range params.WorkspaceFolders {
+		if folderPath, ok := pathFromURI(DocumentURI(folder.URI)); ok {
+			folderList = append(folderList, folderPath)
+		}
+	}
+
+	if rootPath, ok := pathFromURI(params.RootURI); ok && len(folderList) == 0 {
+		folderList = append(folderList, rootPath)
+	}
+
+	settings := Settings{ConfigPath: "", Config: Config.Default()}
+
+	var err error
+
+	if len(params.InitializationOptions) > 0 {
+		settings, err = ParseSettings(params.InitializationOptions)
+		if err != nil {
+			settings = Settings{ConfigPath: "", Config: Config.Default()}
+		}
+	}
+
+	session.workspaceLock.Lock()
+	defer session.workspaceLock.Unlock()
+
+	session.workspace = workspace{
+		folderList:       folderList,
+		settings:         settings,
+		watchConfigFiles: params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration,
+	}
+
+	return err
+}
+
+// updateSettings replaces the settings of the workspace of the session.
+func (session *session) updateSettings(settings Settings) {
+	session.workspaceLock.Lock()
+	defer session.workspaceLock.Unlock()
+
+	session.workspace.settings = settings
+}
+
+// getWorkspace returns the current state of the workspace of the session.
+func (session *session) getWorkspace() workspace {
+	session.workspaceLock.Lock()
+	defer session.workspaceLock.Unlock()
+
+	return session.workspace
+}
+
+// folderOf returns the innermost workspace folder, that contains filePath, or an empty string if there is none.
+func (workspace workspace) folderOf(filePath string) string {
+	result := ""
+
+	for _, folder := range workspace.folderList {
+		relPath, err := filepath.Rel(folder, filePath)
+		if err != nil || strings.HasPrefix(relPath, "..") {
+			continue
+		}
+
+		if len(folder) > len(result) {
+			result = folder
+		}
+	}
+
+	return result
+}
+
+// documentConfig returns the config of the document of uri, that is its config file overridden by the settings.
+// An invalid config file is logged and ignored, so the document is still linted.
+func (workspace workspace) documentConfig(uri DocumentURI) *Config.Config {
+	configPath := workspace.settings.ConfigPath
+	documentPath, isFile := pathFromURI(uri)
+
+	if configPath != "" && !filepath.IsAbs(configPath) && isFile {
+		if folder := workspace.folderOf(documentPath); folder != "" {
+			configPath = filepath.Join(folder, configPath)
+		}
+	}
+
+	fileConfig := Config.Default()
+
+	// unsaved documents without a path have no config file to discover
+	if isFile || configPath != "" {
+		resolvedConfig, err := Config.Resolve(configPath, documentPath)
+		if err != nil {
+			Log.Error("Cannot load the config of ", uri, " | ", err)
+		} else {
+			fileConfig = resolvedConfig
+		}
+	}
+
+	return fileConfig.Merge(workspace.settings.Config)
+}
+
+// pathFromURI returns the file path of a file URI.
+func pathFromURI(uri DocumentURI) (string, bool) {
+	parsedURL, err := url.Parse(string(uri))
+	if err != nil || parsedURL.Scheme != "file" {
+		return "", false
+	}
+
+	return filepath.FromSlash(parsedURL.Path), true
+}
+
+// isConfigFile checks, whether the file of uri is a config file, based on its name.
+func isConfigFile(uri DocumentURI) bool {
+	filePath, ok := pathFromURI(uri)
+
+	return ok && Utils.EqualsEither(filepath.Base(filePath), Config.FileNameList)
+}
@@ -0,0 +1,70 @@
+package lsp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	LSP "github.com/cremindes/whalelint/lsp"
+)
+
+func TestParseSettings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name               string
+		SettingsJSON       string
+		IsValid            bool
+		ExpectedConfigPath string
+		ExpectedDisable    []string
+	}{
+		{
+			Name:               "Settings without section.",
+			SettingsJSON:       `{"configPath": "build/.whalelint.yml", "disable": ["run002"]}`,
+			IsValid:            true,
+			ExpectedConfigPath: "build/.whalelint.yml",
+			ExpectedDisable:    []string{"RUN002"},
+		},
+		{
+			Name:            "Settings under the whalelint section.",
+			SettingsJSON:    `{"whalelint": {"disable": ["STS001"]}, "otherTool": {"disable": true}}`,
+			IsValid:         true,
+			ExpectedDisable: []string{"STS001"},
+		},
+		{
+			Name:            "Null settings.",
+			SettingsJSON:    `null`,
+			IsValid:         true,
+			ExpectedDisable: []string{},
+		},
+		{
+			Name:         "Invalid severity.",
+			SettingsJSON: `{"rules": {"RUN009": {"severity": "Fatal"}}}`,
+			IsValid:      false,
+		},
+		{
+			Name:         "Invalid config path.",
+			SettingsJSON: `{"configPath": 42}`,
+			IsValid:      false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			settings, err := LSP.ParseSettings([]byte(testCase.SettingsJSON))
+
+			assert.Equal(t, testCase.IsValid, err == nil)
+
+			if !testCase.IsValid {
+				return
+			}
+
+			assert.Equal(t, testCase.ExpectedConfigPath, settings.ConfigPath)
+			assert.Equal(t, testCase.ExpectedDisable, settings.Config.Disable)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/alecthomas/kong"
@@ -47,6 +48,13 @@ func main() {
 	)
 
 	if err != nil {
+		// the exit code was requested by --return-value and/or --fail-on, the report has already been printed
+		var exitCodeError *CLI.ExitCodeError
+		if errors.As(err, &exitCodeError) {
+			os.Exit(exitCodeError.Code)
+		}
+
 		log.Error(err)
+		os.Exit(1)
 	}
 }
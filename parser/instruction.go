@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// InvalidInstruction is a Dockerfile instruction, that buildkit cannot build a command from, e.g. because of an
+// unknown flag or a missing argument. As it's left out of the stages, it's validated on its own, so it's reported
+// instead of being silently skipped.
+type InvalidInstruction struct {
+	Node *parser.Node
+	Err  error
+}
+
+// Keyword returns the instruction keyword in upper case, e.g. ADD.
+func (invalidInstruction *InvalidInstruction) Keyword() string {
+	return strings.ToUpper(invalidInstruction.Node.Value)
+}
+
+// Location returns the line ranges of the instruction.
+func (invalidInstruction *InvalidInstruction) Location() []parser.Range {
+	return invalidInstruction.Node.Location()
+}
+
+// ParseInvalidInstructionList returns the instructions of the Dockerfile source, that buildkit cannot build a command
+// from, in order of appearance. A source, that cannot be parsed at all, has none. The newer flags, that Docker builds,
+// are removed first, see Utils.RemoveNewerFlags.
+func ParseInvalidInstructionList(source string) []*InvalidInstruction {
+	dockerfile, err := parser.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil
+	}
+
+	Utils.RemoveNewerFlags(dockerfile.AST)
+
+	invalidInstructionList := make([]*InvalidInstruction, 0)
+
+	for _, node := range dockerfile.AST.Children {
+		if _, err := instructions.ParseInstruction(node); err != nil {
+			invalidInstructionList = append(invalidInstructionList, &InvalidInstruction{Node: node, Err: err})
+		}
+	}
+
+	return invalidInstructionList
+}
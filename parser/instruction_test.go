@@ -0,0 +1,62 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+func TestParseInvalidInstructionList(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		DockerfileStr string
+		Expected      []string // keyword and error of each invalid instruction
+	}{
+		{
+			Name:          "Valid Dockerfile.",
+			DockerfileStr: "FROM golang:1.17\nRUN make\n",
+			Expected:      []string{},
+		},
+		{
+			Name:          "Unknown flag.",
+			DockerfileStr: "FROM golang:1.17\nCOPY --parents src/*/go.mod /app/\n",
+			Expected:      []string{"COPY | unknown flag: parents"},
+		},
+		{
+			Name: "Newer flags.",
+			DockerfileStr: "FROM golang:1.17\n" +
+				"ADD --checksum=sha256:24454f83 https://example.com/app.tar.gz /app/\n" +
+				"ADD --keep-git-dir=true https://github.com/moby/buildkit.git#v0.10.1 /src\n" +
+				"ADD --link src/ /app/\n" +
+				"COPY --link --chown=app:app . /app/\n",
+			Expected: []string{},
+		},
+		{
+			Name:          "Lower case instructions.",
+			DockerfileStr: "from golang:1.17\nworkdir\nrun make\nexpose\n",
+			Expected: []string{
+				"WORKDIR | WORKDIR requires exactly one argument",
+				"EXPOSE | EXPOSE requires at least one argument",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			result := make([]string, 0)
+			for _, invalidInstruction := range Parser.ParseInvalidInstructionList(testCase.DockerfileStr) {
+				result = append(result, invalidInstruction.Keyword()+" | "+invalidInstruction.Err.Error())
+			}
+
+			assert.Equal(t, testCase.Expected, result)
+		})
+	}
+}
@@ -9,13 +9,21 @@ import (
 	Utils "github.com/cremindes/whalelint/utils"
 )
 
-var RawParser RawDockerfileParser = RawDockerfileParser{rawStr: ""} // nolint:gochecknoglobals
-
+// RawDockerfileParser locates strings in the raw Dockerfile content, as the buildkit AST only has the line ranges of
+// the instructions.
 type RawDockerfileParser struct {
 	rawStr   string
 	rawLines []string
 }
 
+// NewRawDockerfileParser returns a parser of the raw Dockerfile content str.
+func NewRawDockerfileParser(str string) RawDockerfileParser {
+	r := RawDockerfileParser{rawStr: "", rawLines: nil}
+	r.UpdateRawStr(str)
+
+	return r
+}
+
 func (r *RawDockerfileParser) IsInitialized() bool {
 	return len(r.rawStr) > 0 && len(r.rawLines) > 0
 }
@@ -28,6 +36,10 @@ func (r *RawDockerfileParser) ParseRawLineRange(p []parser.Range) []string {
 	return r.rawLines[p[0].Start.Line-1 : p[len(p)-1].End.Line]
 }
 
+func (r *RawDockerfileParser) RawStr() string {
+	return r.rawStr
+}
+
 func (r *RawDockerfileParser) UpdateRawStr(str string) {
 	r.rawStr = str
 	r.rawLines = strings.Split(r.rawStr, "\n")
@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// SuppressionKind represents the scope of an inline suppression directive.
+type SuppressionKind int
+
+const (
+	SuppressNextInstruction SuppressionKind = iota // "# whalelint:ignore ID" comment line before an instruction
+	SuppressInstruction                            // "# whalelint:ignore ID" inside or at the end of an instruction
+	SuppressFile                                   // "# whalelint:ignore-file ID" anywhere in the Dockerfile
+	SuppressBlock                                  // "# whalelint:ignore-begin ID" ... "# whalelint:ignore-end"
+)
+
+// Suppression is an inline directive, that suppresses the listed rules in a range of lines. An empty rule ID list
+// means every rule. Forms:
+//
+//	# whalelint:ignore RUN002,RUN009   -> next instruction, or the instruction itself if it's inside of it
+//	# whalelint:ignore-file STS001     -> whole Dockerfile
+//	# whalelint:ignore-begin RUN002    -> till the matching ignore-end or the end of the Dockerfile
+//	# whalelint:ignore-end
+type Suppression struct {
+	Kind       SuppressionKind
+	RuleIDList []string
+	Location   [4]int // directive location as start line, start char, end line, end char
+	Text       string // directive source, e.g. "# whalelint:ignore RUN002"
+	StartLine  int    // first suppressed line
+	EndLine    int    // last suppressed line
+
+	usedRuleIDSet map[string]bool
+}
+
+// Suppresses tells whether the rule with ruleID is suppressed on lineNumber. A match marks the suppression as used.
+func (suppression *Suppression) Suppresses(ruleID string, lineNumber int) bool {
+	if lineNumber < suppression.StartLine || suppression.EndLine < lineNumber {
+		return false
+	}
+
+	ruleID = strings.ToUpper(ruleID)
+
+	if len(suppression.RuleIDList) > 0 && !Utils.EqualsEither(ruleID, suppression.RuleIDList) {
+		return false
+	}
+
+	suppression.usedRuleIDSet[ruleID] = true
+
+	return true
+}
+
+// IsUsed tells whether the suppression has suppressed anything at all.
+func (suppression *Suppression) IsUsed() bool {
+	return len(suppression.usedRuleIDSet) > 0
+}
+
+// UnusedRuleIDList returns the explicitly listed rule IDs that have not suppressed anything.
+func (suppression *Suppression) UnusedRuleIDList() []string {
+	result := make([]string, 0)
+
+	for _, ruleID := range suppression.RuleIDList {
+		if !suppression.usedRuleIDSet[ruleID] {
+			result = append(result, ruleID)
+		}
+	}
+
+	return result
+}
+
+// suppressionDirective is a raw, not yet resolved directive.
+type suppressionDirective struct {
+	keyword    string
+	ruleIDList []string
+	location   [4]int
+	text       string
+	isComment  bool // the directive is on its own comment line
+}
+
+// ParseSuppressionList parses the inline suppression directives from the raw Dockerfile string and resolves their
+// suppressed line ranges based on the instruction ranges.
+// nolint:funlen
+func ParseSuppressionList(rawStr string, instructionRangeList []parser.Range) []*Suppression {
+	suppressionList := make([]*Suppression, 0)
+	blockStack := make([]*Suppression, 0)
+
+	sort.Slice(instructionRangeList, func(i, j int) bool {
+		return instructionRangeList[i].Start.Line < instructionRangeList[j].Start.Line
+	})
+
+	for _, directive := range parseSuppressionDirectiveList(rawStr) {
+		lineNumber := directive.location[0]
+		suppression := &Suppression{
+			RuleIDList:    directive.ruleIDList,
+			Location:      directive.location,
+			Text:          directive.text,
+			StartLine:     lineNumber,
+			EndLine:       lineNumber,
+			usedRuleIDSet: map[string]bool{},
+		}
+
+		switch directive.keyword {
+		case "ignore":
+			if instructionRange, ok := findInstructionRange(instructionRangeList, lineNumber); ok {
+				suppression.Kind = SuppressInstruction
+				suppression.StartLine, suppression.EndLine = instructionRange.Start.Line, instructionRange.End.Line
+			} else if directive.isComment {
+				suppression.Kind = SuppressNextInstruction
+				// nothing to suppress, unless there is an instruction after the directive
+				suppression.StartLine, suppression.EndLine = 0, -1
+
+				if instructionRange, ok := findNextInstructionRange(instructionRangeList, lineNumber); ok {
+					suppression.StartLine, suppression.EndLine = instructionRange.Start.Line, instructionRange.End.Line
+				}
+			} else {
+				continue
+			}
+		case "ignore-file":
+			suppression.Kind = SuppressFile
+			suppression.StartLine, suppression.EndLine = 1, math.MaxInt32
+		case "ignore-begin":
+			suppression.Kind = SuppressBlock
+			suppression.EndLine = math.MaxInt32 // in case there is no matching ignore-end
+			blockStack = append(blockStack, suppression)
+		case "ignore-end":
+			// close the innermost block
+			if len(blockStack) > 0 {
+				blockStack[len(blockStack)-1].EndLine = lineNumber
+				blockStack = blockStack[:len(blockStack)-1]
+			}
+
+			continue
+		}
+
+		suppressionList = append(suppressionList, suppression)
+	}
+
+	return suppressionList
+}
+
+func parseSuppressionDirectiveList(rawStr string) []suppressionDirective {
+	regexpDirective := regexp.MustCompile(`#\s*whalelint:(ignore-file|ignore-begin|ignore-end|ignore)\b([^#]*)`)
+	regexpRuleID := regexp.MustCompile(`^[A-Za-z]{3}[0-9]{3}$`)
+
+	directiveList := make([]suppressionDirective, 0)
+
+	for i, line := range strings.Split(rawStr, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		matchIndexList := regexpDirective.FindStringSubmatchIndex(line)
+		if matchIndexList == nil {
+			continue
+		}
+
+		ruleIDList := make([]string, 0)
+
+		for _, ruleID := range strings.FieldsFunc(line[matchIndexList[4]:matchIndexList[5]], isRuleIDSeparator) {
+			if regexpRuleID.MatchString(ruleID) {
+				ruleIDList = append(ruleIDList, strings.ToUpper(ruleID))
+			}
+		}
+
+		endChar := len(strings.TrimRight(line, " \t"))
+
+		directiveList = append(directiveList, suppressionDirective{
+			keyword:    line[matchIndexList[2]:matchIndexList[3]],
+			ruleIDList: ruleIDList,
+			location:   [4]int{i + 1, matchIndexList[0], i + 1, endChar},
+			text:       line[matchIndexList[0]:endChar],
+			isComment:  strings.HasPrefix(strings.TrimSpace(line), "#"),
+		})
+	}
+
+	return directiveList
+}
+
+func isRuleIDSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t'
+}
+
+// findInstructionRange returns the instruction range that contains lineNumber.
+func findInstructionRange(instructionRangeList []parser.Range, lineNumber int) (parser.Range, bool) {
+	for _, instructionRange := range instructionRangeList {
+		if instructionRange.Start.Line <= lineNumber && lineNumber <= instructionRange.End.Line {
+			return instructionRange, true
+		}
+	}
+
+	return parser.Range{}, false
+}
+
+// findNextInstructionRange returns the first instruction range that starts after lineNumber.
+func findNextInstructionRange(instructionRangeList []parser.Range, lineNumber int) (parser.Range, bool) {
+	for _, instructionRange := range instructionRangeList {
+		if instructionRange.Start.Line > lineNumber {
+			return instructionRange, true
+		}
+	}
+
+	return parser.Range{}, false
+}
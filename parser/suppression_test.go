@@ -0,0 +1,136 @@
+package parser_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/assert"
+
+	Parser "github.com/cremindes/whalelint/parser"
+)
+
+func lineRange(startLine, endLine int) parser.Range {
+	return parser.Range{
+		Start: parser.Position{Line: startLine, Character: 0},
+		End:   parser.Position{Line: endLine, Character: 0},
+	}
+}
+
+func TestParseSuppressionList(t *testing.T) {
+	t.Parallel()
+
+	type expectedSuppression struct {
+		Kind       Parser.SuppressionKind
+		RuleIDList []string
+		StartLine  int
+		EndLine    int
+	}
+
+	testCases := []struct {
+		Name                 string
+		DockerfileStr        string
+		InstructionRangeList []parser.Range
+		Expected             []expectedSuppression
+	}{
+		{
+			Name:                 "Next instruction.",
+			DockerfileStr:        "FROM golang:1.17\n# whalelint:ignore RUN002, run009\n\nRUN apt-get install \\\n  vim",
+			InstructionRangeList: []parser.Range{lineRange(1, 1), lineRange(4, 5)},
+			Expected: []expectedSuppression{
+				{Kind: Parser.SuppressNextInstruction, RuleIDList: []string{"RUN002", "RUN009"}, StartLine: 4, EndLine: 5},
+			},
+		},
+		{
+			Name:                 "Same instruction.",
+			DockerfileStr:        "FROM golang:1.17\nRUN sudo apt-get install vim # whalelint:ignore RUN002",
+			InstructionRangeList: []parser.Range{lineRange(1, 1), lineRange(2, 2)},
+			Expected: []expectedSuppression{
+				{Kind: Parser.SuppressInstruction, RuleIDList: []string{"RUN002"}, StartLine: 2, EndLine: 2},
+			},
+		},
+		{
+			Name:                 "Inside a multi-line instruction.",
+			DockerfileStr:        "FROM golang:1.17\nRUN apt-get update && \\\n# whalelint:ignore\n  apt-get install vim",
+			InstructionRangeList: []parser.Range{lineRange(1, 1), lineRange(2, 4)},
+			Expected: []expectedSuppression{
+				{Kind: Parser.SuppressInstruction, RuleIDList: []string{}, StartLine: 2, EndLine: 4},
+			},
+		},
+		{
+			Name:                 "Whole file.",
+			DockerfileStr:        "# whalelint:ignore-file STS001\nFROM golang:1.17",
+			InstructionRangeList: []parser.Range{lineRange(2, 2)},
+			Expected: []expectedSuppression{
+				{Kind: Parser.SuppressFile, RuleIDList: []string{"STS001"}, StartLine: 1, EndLine: math.MaxInt32},
+			},
+		},
+		{
+			Name: "Block with and without end.",
+			DockerfileStr: "FROM golang:1.17\n# whalelint:ignore-begin RUN002\nRUN sudo ls\n# whalelint:ignore-end\n" +
+				"# whalelint:ignore-begin RUN009\nRUN apt-get install vim",
+			InstructionRangeList: []parser.Range{lineRange(1, 1), lineRange(3, 3), lineRange(6, 6)},
+			Expected: []expectedSuppression{
+				{Kind: Parser.SuppressBlock, RuleIDList: []string{"RUN002"}, StartLine: 2, EndLine: 4},
+				{Kind: Parser.SuppressBlock, RuleIDList: []string{"RUN009"}, StartLine: 5, EndLine: math.MaxInt32},
+			},
+		},
+		{
+			Name:                 "Next instruction missing.",
+			DockerfileStr:        "FROM golang:1.17\n# whalelint:ignore RUN002",
+			InstructionRangeList: []parser.Range{lineRange(1, 1)},
+			Expected: []expectedSuppression{
+				{Kind: Parser.SuppressNextInstruction, RuleIDList: []string{"RUN002"}, StartLine: 0, EndLine: -1},
+			},
+		},
+		{
+			Name:                 "No directive.",
+			DockerfileStr:        "FROM golang:1.17\n# just a comment\nRUN echo whalelint",
+			InstructionRangeList: []parser.Range{lineRange(1, 1), lineRange(3, 3)},
+			Expected:             []expectedSuppression{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			suppressionList := Parser.ParseSuppressionList(testCase.DockerfileStr, testCase.InstructionRangeList)
+
+			assert.Equal(t, len(testCase.Expected), len(suppressionList))
+
+			for i, expected := range testCase.Expected {
+				assert.Equal(t, expected, expectedSuppression{
+					Kind:       suppressionList[i].Kind,
+					RuleIDList: suppressionList[i].RuleIDList,
+					StartLine:  suppressionList[i].StartLine,
+					EndLine:    suppressionList[i].EndLine,
+				})
+			}
+		})
+	}
+}
+
+func TestSuppression_Suppresses(t *testing.T) {
+	t.Parallel()
+
+	suppressionList := Parser.ParseSuppressionList(
+		"FROM golang:1.17\n# whalelint:ignore RUN002 RUN009\nRUN sudo apt-get install vim",
+		[]parser.Range{lineRange(1, 1), lineRange(3, 3)},
+	)
+	assert.Equal(t, 1, len(suppressionList))
+
+	suppression := suppressionList[0]
+	assert.Equal(t, [4]int{2, 0, 2, 32}, suppression.Location)
+	assert.False(t, suppression.IsUsed())
+
+	assert.False(t, suppression.Suppresses("RUN002", 1))
+	assert.False(t, suppression.Suppresses("RUN010", 3))
+	assert.False(t, suppression.IsUsed())
+
+	assert.True(t, suppression.Suppresses("run002", 3))
+	assert.True(t, suppression.IsUsed())
+	assert.Equal(t, []string{"RUN009"}, suppression.UnusedRuleIDList())
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// CheckstyleVersion is the version of the Checkstyle XML format, as expected by most consumers, e.g. Jenkins.
+const CheckstyleVersion = "4.3"
+
+type CheckstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+type CheckstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single violation. Line and Column are 1-based.
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// CheckstyleSeverity maps a Severity to a Checkstyle severity.
+func CheckstyleSeverity(severity RuleSet.Severity) string {
+	switch severity {
+	case RuleSet.ValError:
+		return "error"
+	case RuleSet.ValWarning:
+		return "warning"
+	case RuleSet.ValInfo, RuleSet.ValDeprecation, RuleSet.ValUnknown:
+		return "info"
+	}
+
+	return "info"
+}
+
+// NewCheckstyleReport converts the rule validation results into a Checkstyle report with one file element per
+// Dockerfile, even if it has no violations.
+func NewCheckstyleReport(ruleValidationResultArray []RuleSet.RuleValidationResult) CheckstyleReport {
+	checkstyleReport := CheckstyleReport{Version: CheckstyleVersion, Files: []CheckstyleFile{}}
+
+	for _, filePath := range getFilePathList(ruleValidationResultArray) {
+		checkstyleFile := CheckstyleFile{Name: filePath, Errors: []CheckstyleError{}}
+
+		for _, result := range filterByFilePath(ruleValidationResultArray, filePath) {
+			if !result.IsViolated() {
+				continue
+			}
+
+			line, column := 1, 1
+			if start := result.LocationRange.Start(); start != nil && start.LineNumber() > 0 {
+				line, column = start.LineNumber(), start.CharNumber()+1
+			}
+
+			checkstyleFile.Errors = append(checkstyleFile.Errors, CheckstyleError{
+				Line:     line,
+				Column:   column,
+				Severity: CheckstyleSeverity(result.Severity()),
+				Message:  result.Message(),
+				Source:   "whalelint." + result.RuleID(),
+			})
+		}
+
+		sort.SliceStable(checkstyleFile.Errors, func(i, j int) bool {
+			return checkstyleFile.Errors[i].Line < checkstyleFile.Errors[j].Line
+		})
+
+		checkstyleReport.Files = append(checkstyleReport.Files, checkstyleFile)
+	}
+
+	return checkstyleReport
+}
+
+// PrintResultAsCheckstyle prints lint rule violations to writer in Checkstyle XML format.
+func PrintResultAsCheckstyle(ruleValidationResultArray []RuleSet.RuleValidationResult, writer io.Writer) {
+	printXML(NewCheckstyleReport(ruleValidationResultArray), writer)
+}
+
+// printXML prints v as an indented XML document with header to writer.
+func printXML(v interface{}, writer io.Writer) {
+	resultXML, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Error(err)
+	}
+
+	printToOutput(xml.Header+string(resultXML)+"\n", writer)
+}
@@ -0,0 +1,53 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Report "github.com/cremindes/whalelint/report"
+)
+
+func TestPrintResultAsCheckstyle(t *testing.T) {
+	t.Parallel()
+
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "RUN004", true, 3, "Dockerfile"),
+		newResult(t, "STS001", true, 1, "Dockerfile"),
+		newResult(t, "STS001", false, 1, "api/Dockerfile"),
+	}
+
+	buffer := &bytes.Buffer{}
+	Report.PrintResultAsCheckstyle(results, buffer)
+
+	assert.Equal(t, xml.Header, buffer.String()[:len(xml.Header)])
+
+	var checkstyleReport Report.CheckstyleReport
+
+	assert.Nil(t, xml.Unmarshal(buffer.Bytes(), &checkstyleReport))
+	assert.Equal(t, "4.3", checkstyleReport.Version)
+	assert.Equal(t, 2, len(checkstyleReport.Files))
+
+	// violations are sorted by line
+	assert.Equal(t, "Dockerfile", checkstyleReport.Files[0].Name)
+	assert.Equal(t, []Report.CheckstyleError{
+		{Line: 1, Column: 1, Severity: "warning", Message: "Mock message.", Source: "whalelint.STS001"},
+		{Line: 3, Column: 1, Severity: "warning", Message: "Mock message.", Source: "whalelint.RUN004"},
+	}, checkstyleReport.Files[0].Errors)
+
+	// files without violations are listed too
+	assert.Equal(t, "api/Dockerfile", checkstyleReport.Files[1].Name)
+	assert.Equal(t, 0, len(checkstyleReport.Files[1].Errors))
+}
+
+func TestCheckstyleSeverity(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "error", Report.CheckstyleSeverity(RuleSet.ValError))
+	assert.Equal(t, "warning", Report.CheckstyleSeverity(RuleSet.ValWarning))
+	assert.Equal(t, "info", Report.CheckstyleSeverity(RuleSet.ValInfo))
+	assert.Equal(t, "info", Report.CheckstyleSeverity(RuleSet.ValDeprecation))
+}
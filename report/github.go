@@ -0,0 +1,78 @@
+package report
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// GitHubLevel maps a Severity to a GitHub Actions workflow command, i.e. annotation level.
+func GitHubLevel(severity RuleSet.Severity) string {
+	switch severity {
+	case RuleSet.ValError:
+		return "error"
+	case RuleSet.ValWarning, RuleSet.ValDeprecation:
+		return "warning"
+	case RuleSet.ValInfo, RuleSet.ValUnknown:
+		return "notice"
+	}
+
+	return "notice"
+}
+
+// PrintResultAsGitHubAnnotations prints lint rule violations to writer as GitHub Actions workflow commands, e.g.
+// "::warning file=Dockerfile,line=1,col=6,endLine=1,endColumn=12,title=STS001::Image should have an explicit tag.",
+// so they show up as annotations on the pull request.
+func PrintResultAsGitHubAnnotations(ruleValidationResultArray []RuleSet.RuleValidationResult, writer io.Writer) {
+	violationList := make([]RuleSet.RuleValidationResult, 0)
+
+	for _, result := range ruleValidationResultArray {
+		if result.IsViolated() {
+			violationList = append(violationList, result)
+		}
+	}
+
+	sort.SliceStable(violationList, func(i, j int) bool {
+		if violationList[i].FilePath() != violationList[j].FilePath() {
+			return violationList[i].FilePath() < violationList[j].FilePath()
+		}
+
+		return violationList[i].LocationRange.Start().LineNumber() < violationList[j].LocationRange.Start().LineNumber()
+	})
+
+	strBuilder := &strings.Builder{}
+
+	for i := range violationList {
+		violation := &violationList[i]
+		start, end := violation.LocationRange.Start(), violation.LocationRange.End()
+
+		// workflow command columns are 1-based
+		propertyList := []string{
+			"file=" + escapeGitHubProperty(violation.FilePath()),
+			"line=" + strconv.Itoa(start.LineNumber()),
+			"col=" + strconv.Itoa(start.CharNumber()+1),
+			"endLine=" + strconv.Itoa(end.LineNumber()),
+			"endColumn=" + strconv.Itoa(end.CharNumber()+1),
+			"title=" + escapeGitHubProperty(violation.RuleID()),
+		}
+
+		strBuilder.WriteString("::" + GitHubLevel(violation.Severity()) + " " + strings.Join(propertyList, ","))
+		strBuilder.WriteString("::" + escapeGitHubData(violation.Message()))
+		strBuilder.WriteRune('\n')
+	}
+
+	printToOutput(strBuilder.String(), writer)
+}
+
+// escapeGitHubData escapes the message of a workflow command.
+func escapeGitHubData(str string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(str)
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command.
+func escapeGitHubProperty(str string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeGitHubData(str))
+}
@@ -0,0 +1,45 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Report "github.com/cremindes/whalelint/report"
+)
+
+func TestPrintResultAsGitHubAnnotations(t *testing.T) {
+	t.Parallel()
+
+	rule := RuleSet.Get().GetRuleByName("RUN003", nil)
+	location := RuleSet.NewLocationRange(2, 4, 3, 8)
+	multiLineResult := RuleSet.NewRuleValidationResult(&rule, true, "50% off,\nreally", location)
+	multiLineResult.SetFilePath("api/Dockerfile")
+
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "STS001", true, 3, "Dockerfile"),
+		newResult(t, "RUN004", false, 2, "Dockerfile"),
+		*multiLineResult,
+		newResult(t, "RUN004", true, 1, "Dockerfile"),
+	}
+
+	strBuilder := &strings.Builder{}
+	Report.PrintResultAsGitHubAnnotations(results, strBuilder)
+
+	assert.Equal(t,
+		"::warning file=Dockerfile,line=1,col=1,endLine=1,endColumn=11,title=RUN004::Mock message.\n"+
+			"::warning file=Dockerfile,line=3,col=1,endLine=3,endColumn=11,title=STS001::Mock message.\n"+
+			"::error file=api/Dockerfile,line=2,col=5,endLine=3,endColumn=9,title=RUN003::50%25 off,%0Areally\n",
+		strBuilder.String())
+}
+
+func TestGitHubLevel(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "error", Report.GitHubLevel(RuleSet.ValError))
+	assert.Equal(t, "warning", Report.GitHubLevel(RuleSet.ValWarning))
+	assert.Equal(t, "warning", Report.GitHubLevel(RuleSet.ValDeprecation))
+	assert.Equal(t, "notice", Report.GitHubLevel(RuleSet.ValInfo))
+}
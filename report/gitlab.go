@@ -0,0 +1,98 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// GitLabIssue is an issue of the GitLab Code Quality report, which is a subset of the Code Climate spec.
+// See https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool.
+type GitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    GitLabLocation `json:"location"`
+}
+
+type GitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines GitLabLines `json:"lines"`
+}
+
+type GitLabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// GitLabSeverity maps a Severity to a GitLab Code Quality severity.
+func GitLabSeverity(severity RuleSet.Severity) string {
+	switch severity {
+	case RuleSet.ValError:
+		return "major"
+	case RuleSet.ValWarning, RuleSet.ValDeprecation:
+		return "minor"
+	case RuleSet.ValInfo, RuleSet.ValUnknown:
+		return "info"
+	}
+
+	return "info"
+}
+
+// NewGitLabIssueList converts lint rule violations into GitLab Code Quality issues. The fingerprints are derived from
+// the rule ID, file path and normalized instruction source code, so an issue keeps its identity between merge request
+// pipelines even if it moves to another line. Repeated violations of the same instruction are numbered.
+func NewGitLabIssueList(ruleValidationResultArray []RuleSet.RuleValidationResult) []GitLabIssue {
+	issueList := make([]GitLabIssue, 0)
+	fingerprintCountMap := make(map[string]int)
+
+	for i := range ruleValidationResultArray {
+		result := &ruleValidationResultArray[i]
+		if !result.IsViolated() {
+			continue
+		}
+
+		fingerprint := result.Fingerprint()
+
+		fingerprintCountMap[fingerprint]++
+		if count := fingerprintCountMap[fingerprint]; count > 1 {
+			hash := sha256.Sum256([]byte(fingerprint + "#" + strconv.Itoa(count)))
+			fingerprint = hex.EncodeToString(hash[:])
+		}
+
+		issueList = append(issueList, GitLabIssue{
+			Description: result.RuleID() + " | " + result.Message(),
+			CheckName:   result.RuleID(),
+			Fingerprint: fingerprint,
+			Severity:    GitLabSeverity(result.Severity()),
+			Location: GitLabLocation{
+				Path: strings.TrimPrefix(filepath.ToSlash(result.FilePath()), "./"),
+				Lines: GitLabLines{
+					Begin: result.LocationRange.Start().LineNumber(),
+					End:   result.LocationRange.End().LineNumber(),
+				},
+			},
+		})
+	}
+
+	return issueList
+}
+
+// PrintResultAsGitLabCodeQuality prints lint rule violations to writer in GitLab Code Quality JSON format.
+func PrintResultAsGitLabCodeQuality(ruleValidationResultArray []RuleSet.RuleValidationResult, writer io.Writer) {
+	resultJSON, err := json.MarshalIndent(NewGitLabIssueList(ruleValidationResultArray), "", "  ")
+	if err != nil {
+		log.Error(err)
+	}
+
+	printToOutput(string(resultJSON)+"\n", writer)
+}
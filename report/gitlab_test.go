@@ -0,0 +1,54 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Report "github.com/cremindes/whalelint/report"
+)
+
+func TestPrintResultAsGitLabCodeQuality(t *testing.T) {
+	t.Parallel()
+
+	newInstructionResult := func(ruleID string, line int, filePath, instruction string) RuleSet.RuleValidationResult {
+		result := newResult(t, ruleID, true, line, filePath)
+		result.SetInstruction(instruction)
+
+		return result
+	}
+
+	results := []RuleSet.RuleValidationResult{
+		newInstructionResult("RUN004", 2, "./Dockerfile", "RUN sudo ls"),
+		newInstructionResult("RUN004", 4, "./Dockerfile", "RUN sudo ls"),
+		newInstructionResult("STS001", 1, "./Dockerfile", "FROM golang"),
+		newResult(t, "RUN009", false, 3, "./Dockerfile"),
+	}
+
+	buffer := &bytes.Buffer{}
+	Report.PrintResultAsGitLabCodeQuality(results, buffer)
+
+	var issueList []Report.GitLabIssue
+
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &issueList))
+	assert.Equal(t, 3, len(issueList))
+
+	assert.Equal(t, Report.GitLabIssue{
+		Description: "RUN004 | Mock message.",
+		CheckName:   "RUN004",
+		Fingerprint: results[0].Fingerprint(),
+		Severity:    "minor",
+		Location:    Report.GitLabLocation{Path: "Dockerfile", Lines: Report.GitLabLines{Begin: 2, End: 2}},
+	}, issueList[0])
+
+	// the same instruction is violated twice, but the fingerprints are still unique
+	assert.NotEqual(t, issueList[0].Fingerprint, issueList[1].Fingerprint)
+	assert.NotEqual(t, issueList[1].Fingerprint, issueList[2].Fingerprint)
+
+	// fingerprints are stable
+	issueListAgain := Report.NewGitLabIssueList(results)
+	assert.Equal(t, issueList[1].Fingerprint, issueListAgain[1].Fingerprint)
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+type JUnitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite holds the test cases of a single Dockerfile.
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase represents a single rule validated against a Dockerfile. It fails, if there is any violation.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// NewJUnitTestSuites converts the rule validation results into JUnit test suites. There is a test suite per
+// Dockerfile and a test case per (rule, Dockerfile) pair, so the passing rules are visible too.
+func NewJUnitTestSuites(ruleValidationResultArray []RuleSet.RuleValidationResult) JUnitTestSuites {
+	testSuites := JUnitTestSuites{Name: "WhaleLint", TestSuites: []JUnitTestSuite{}}
+
+	for _, filePath := range getFilePathList(ruleValidationResultArray) {
+		testSuite := JUnitTestSuite{Name: filePath, TestCases: []JUnitTestCase{}}
+
+		// group the results of the Dockerfile by rule
+		ruleResultMap := make(map[string][]RuleSet.RuleValidationResult)
+		for _, result := range filterByFilePath(ruleValidationResultArray, filePath) {
+			ruleResultMap[result.RuleID()] = append(ruleResultMap[result.RuleID()], result)
+		}
+
+		ruleIDList := make([]string, 0, len(ruleResultMap))
+		for ruleID := range ruleResultMap {
+			ruleIDList = append(ruleIDList, ruleID)
+		}
+
+		sort.Strings(ruleIDList)
+
+		for _, ruleID := range ruleIDList {
+			testCase := newJUnitTestCase(ruleID, filePath, ruleResultMap[ruleID])
+			if testCase.Failure != nil {
+				testSuite.Failures++
+			}
+
+			testSuite.TestCases = append(testSuite.TestCases, testCase)
+		}
+
+		testSuite.Tests = len(testSuite.TestCases)
+		testSuites.Tests += testSuite.Tests
+		testSuites.Failures += testSuite.Failures
+		testSuites.TestSuites = append(testSuites.TestSuites, testSuite)
+	}
+
+	return testSuites
+}
+
+// PrintResultAsJUnit prints lint rule validation results to writer in JUnit XML format.
+func PrintResultAsJUnit(ruleValidationResultArray []RuleSet.RuleValidationResult, writer io.Writer) {
+	printXML(NewJUnitTestSuites(ruleValidationResultArray), writer)
+}
+
+func newJUnitTestCase(ruleID string, filePath string, resultList []RuleSet.RuleValidationResult) JUnitTestCase {
+	testCase := JUnitTestCase{Name: ruleID, ClassName: filePath}
+	violationList := make([]RuleSet.RuleValidationResult, 0)
+
+	for _, result := range resultList {
+		if result.IsViolated() {
+			violationList = append(violationList, result)
+		}
+	}
+
+	if len(violationList) == 0 {
+		return testCase
+	}
+
+	sort.SliceStable(violationList, func(i, j int) bool {
+		return violationList[i].LocationRange.Start().LineNumber() < violationList[j].LocationRange.Start().LineNumber()
+	})
+
+	// Line nnn | RuleValidation.Message
+	strBuilder := strings.Builder{}
+	for _, violation := range violationList {
+		strBuilder.WriteString("Line " + strconv.Itoa(violation.LocationRange.Start().LineNumber()) + " | ")
+		strBuilder.WriteString(violation.Message())
+		strBuilder.WriteRune('\n')
+	}
+
+	testCase.Failure = &JUnitFailure{
+		Message: violationList[0].Message(),
+		Type:    violationList[0].Severity().String(),
+		Text:    strBuilder.String(),
+	}
+
+	return testCase
+}
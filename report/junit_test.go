@@ -0,0 +1,52 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Report "github.com/cremindes/whalelint/report"
+)
+
+func TestPrintResultAsJUnit(t *testing.T) {
+	t.Parallel()
+
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "STS001", true, 1, "Dockerfile"),
+		newResult(t, "RUN004", true, 4, "Dockerfile"),
+		newResult(t, "RUN004", true, 2, "Dockerfile"),
+		newResult(t, "RUN004", false, 3, "Dockerfile"),
+		newResult(t, "STS001", false, 1, "api/Dockerfile"),
+	}
+
+	buffer := &bytes.Buffer{}
+	Report.PrintResultAsJUnit(results, buffer)
+
+	var testSuites Report.JUnitTestSuites
+
+	assert.Nil(t, xml.Unmarshal(buffer.Bytes(), &testSuites))
+	assert.Equal(t, 3, testSuites.Tests)
+	assert.Equal(t, 2, testSuites.Failures)
+	assert.Equal(t, 2, len(testSuites.TestSuites))
+
+	// one test case per (rule, file) pair, sorted by rule ID
+	testSuite := testSuites.TestSuites[0]
+	assert.Equal(t, "Dockerfile", testSuite.Name)
+	assert.Equal(t, 2, testSuite.Tests)
+	assert.Equal(t, 2, testSuite.Failures)
+	assert.Equal(t, "RUN004", testSuite.TestCases[0].Name)
+	assert.Equal(t, "Dockerfile", testSuite.TestCases[0].ClassName)
+	assert.Equal(t, "Warning", testSuite.TestCases[0].Failure.Type)
+	assert.Equal(t, "Line 2 | Mock message.\nLine 4 | Mock message.\n", testSuite.TestCases[0].Failure.Text)
+	assert.Equal(t, "STS001", testSuite.TestCases[1].Name)
+
+	// passing rules are visible too
+	testSuite = testSuites.TestSuites[1]
+	assert.Equal(t, "api/Dockerfile", testSuite.Name)
+	assert.Equal(t, 1, testSuite.Tests)
+	assert.Equal(t, 0, testSuite.Failures)
+	assert.Nil(t, testSuite.TestCases[0].Failure)
+}
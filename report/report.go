@@ -14,6 +14,7 @@ import (
 	"robpike.io/filter"
 
 	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Utils "github.com/cremindes/whalelint/utils"
 )
 
 // PrintResultAsJSON prints lint rule violations to writer in JSON format.
@@ -107,13 +108,32 @@ func AssembleSummaryHeader(findingsMap FindingsMap, hasViolation bool, printOpti
 	// Header | Start
 	strBuilder.WriteString("WhaleLint summary: ")
 
+	// Header | Body
+	assembleSeverityCounts(findingsMap, hasViolation, printOptions, strBuilder)
+}
+
+// AssembleFileHeader prepares the one line summary header of a Dockerfile, when there are more of them.
+// Format is 'FilePath | Severity counts'.
+func AssembleFileHeader(filePath string, findingsMap FindingsMap, hasViolation bool, printOptions PrintOptions,
+	strBuilder *strings.Builder) {
+	strBuilder.WriteString(color.New(color.Bold).SprintFunc()(filePath) + " | ")
+
+	assembleSeverityCounts(findingsMap, hasViolation, printOptions, strBuilder)
+
+	if !hasViolation {
+		strBuilder.WriteRune('\n')
+	}
+}
+
+// assembleSeverityCounts prepares the number of findings per severity, e.g. "1 Error, 2 Warnings".
+func assembleSeverityCounts(findingsMap FindingsMap, hasViolation bool, printOptions PrintOptions,
+	strBuilder *strings.Builder) {
 	if !hasViolation {
 		strBuilder.WriteString(color.New(color.FgGreen).SprintFunc()("Everything looks good."))
 
 		return
 	}
 
-	// Header | Body
 	hasPrev := false
 
 	severityLevelSlice := RuleSet.GetSeverityList()
@@ -198,6 +218,27 @@ func PrintSummary(violations []RuleSet.RuleValidationResult, writer io.Writer, o
 
 	AssembleSummaryHeader(findingsMap, hasViolation, printOptions, strBuilder)
 
+	filePathList := getFilePathList(violations)
+	if len(filePathList) > 1 {
+		// One section per Dockerfile
+		printConditionally("\n", !hasViolation, strBuilder)
+		strBuilder.WriteRune('\n')
+
+		for _, filePath := range filePathList {
+			fileFindingsMap, fileHasViolation := GroupFindings(filterByFilePath(violations, filePath))
+
+			AssembleFileHeader(filePath, fileFindingsMap, fileHasViolation, printOptions, strBuilder)
+
+			if options.Verbosity != VerbosityShort && fileHasViolation {
+				AssembleSummaryBody(fileFindingsMap, printOptions, strBuilder)
+			}
+		}
+
+		printToOutput(strBuilder.String(), writer)
+
+		return
+	}
+
 	// End of VerbosityShort summary
 	if options.Verbosity == VerbosityShort { // Short Summary ends here
 		printToOutput(strBuilder.String(), writer)
@@ -212,6 +253,32 @@ func PrintSummary(violations []RuleSet.RuleValidationResult, writer io.Writer, o
 	printToOutput(strBuilder.String(), writer)
 }
 
+// getFilePathList returns the distinct file paths of the RuleValidationResult set in order of appearance.
+func getFilePathList(findingList []RuleSet.RuleValidationResult) []string {
+	filePathList := make([]string, 0)
+
+	for _, finding := range findingList {
+		if !Utils.SliceContains(filePathList, finding.FilePath()) {
+			filePathList = append(filePathList, finding.FilePath())
+		}
+	}
+
+	return filePathList
+}
+
+// filterByFilePath returns the RuleValidationResults that belong to the Dockerfile at filePath.
+func filterByFilePath(findingList []RuleSet.RuleValidationResult, filePath string) []RuleSet.RuleValidationResult {
+	result := make([]RuleSet.RuleValidationResult, 0)
+
+	for _, finding := range findingList {
+		if finding.FilePath() == filePath {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
 // getMaxLine returns the highest line locations of a given RuleValidationResult set.
 func getMaxLine(findingList []RuleSet.RuleValidationResult) int {
 	max := 0
@@ -0,0 +1,80 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Report "github.com/cremindes/whalelint/report"
+)
+
+func newResult(t *testing.T, ruleID string, isViolated bool, line int, filePath string) RuleSet.RuleValidationResult {
+	t.Helper()
+
+	rule := RuleSet.Get().GetRuleByName(ruleID, nil)
+	location := RuleSet.NewLocationRange(line, 0, line, 10)
+	result := RuleSet.NewRuleValidationResult(&rule, isViolated, "Mock message.", location)
+	result.SetFilePath(filePath)
+
+	return *result
+}
+
+// nolint:paralleltest
+func TestPrintSummary(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Results   []RuleSet.RuleValidationResult
+		Verbosity Report.VerbosityLevel
+		Expected  string
+	}{
+		{
+			Name: "Single Dockerfile.",
+			Results: []RuleSet.RuleValidationResult{
+				newResult(t, "STS001", true, 1, "Dockerfile"),
+				newResult(t, "RUN004", false, 2, "Dockerfile"),
+			},
+			Verbosity: Report.VerbosityNormal,
+			Expected: "WhaleLint summary: 1 Warning\n\n" +
+				"Warning:\nLine 1 | STS001 | Mock message.\n\n",
+		},
+		{
+			Name: "Multiple Dockerfiles.",
+			Results: []RuleSet.RuleValidationResult{
+				newResult(t, "STS001", true, 1, "Dockerfile"),
+				newResult(t, "RUN004", false, 2, "Dockerfile"),
+				newResult(t, "STS001", false, 1, "api/Dockerfile"),
+			},
+			Verbosity: Report.VerbosityNormal,
+			Expected: "WhaleLint summary: 1 Warning\n\n" +
+				"Dockerfile | 1 Warning\n\nWarning:\nLine 1 | STS001 | Mock message.\n\n" +
+				"api/Dockerfile | Everything looks good.\n",
+		},
+		{
+			Name: "Multiple Dockerfiles, short.",
+			Results: []RuleSet.RuleValidationResult{
+				newResult(t, "STS001", false, 1, "Dockerfile"),
+				newResult(t, "STS001", false, 1, "api/Dockerfile"),
+			},
+			Verbosity: Report.VerbosityShort,
+			Expected: "WhaleLint summary: Everything looks good.\n\n" +
+				"Dockerfile | Everything looks good.\napi/Dockerfile | Everything looks good.\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			// t.Parallel() - PrintSummary sets the global color.NoColor option
+
+			strBuilder := &strings.Builder{}
+			options := Report.SummaryOption{NoColor: true, Verbosity: testCase.Verbosity}
+
+			Report.PrintSummary(testCase.Results, strBuilder, options)
+
+			assert.Equal(t, testCase.Expected, strBuilder.String())
+		})
+	}
+}
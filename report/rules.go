@@ -0,0 +1,105 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// RuleListItem is a rule of the ruleset, as listed by the rules command.
+type RuleListItem struct {
+	ID            string
+	Severity      RuleSet.Severity
+	AstElement    string
+	Definition    string
+	DocsReference RuleSet.DocsReference
+}
+
+// NewRuleListItem collects the listed attributes of rule.
+func NewRuleListItem(rule *RuleSet.Rule) RuleListItem {
+	return RuleListItem{
+		ID:            rule.ID(),
+		Severity:      rule.Severity(),
+		AstElement:    rule.AstElementBin(),
+		Definition:    rule.Definition(),
+		DocsReference: rule.DocsReference(),
+	}
+}
+
+// PrintRuleListAsJSON prints the rules to writer as a JSON array.
+func PrintRuleListAsJSON(ruleList []RuleSet.Rule, writer io.Writer) {
+	ruleListItemList := make([]RuleListItem, 0, len(ruleList))
+	for i := range ruleList {
+		ruleListItemList = append(ruleListItemList, NewRuleListItem(&ruleList[i]))
+	}
+
+	resultJSON, err := json.Marshal(ruleListItemList)
+	if err != nil {
+		log.Error(err)
+	}
+
+	printToOutput(string(resultJSON), writer)
+}
+
+// PrintRuleListAsTable prints the rules to writer as an aligned table, one rule per line.
+func PrintRuleListAsTable(ruleList []RuleSet.Rule, writer io.Writer) {
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0) // nolint:gomnd
+
+	printToOutput("ID\tSEVERITY\tAST ELEMENT\tDEFINITION\tREFERENCE\n", tabWriter)
+
+	for i := range ruleList {
+		item := NewRuleListItem(&ruleList[i])
+		printToOutput(strings.Join([]string{
+			item.ID, item.Severity.String(), item.AstElement, item.Definition, string(item.DocsReference),
+		}, "\t")+"\n", tabWriter)
+	}
+
+	if err := tabWriter.Flush(); err != nil {
+		log.Error(err)
+	}
+}
+
+// PrintRuleExplanation prints the definition, description, examples and reference of rule to writer.
+// The examples are the ones harvested from the rule's test cases, see RuleSet.GetExamples.
+func PrintRuleExplanation(rule *RuleSet.Rule, exampleList []RuleSet.TestCaseDocs, writer io.Writer) {
+	strBuilder := &strings.Builder{}
+
+	strBuilder.WriteString(rule.ID() + " | " + rule.Severity().String() + " | " + rule.AstElementBin() + "\n\n")
+	strBuilder.WriteString(rule.Definition() + "\n")
+
+	if rule.Description() != "" {
+		strBuilder.WriteString("\n" + rule.Description() + "\n")
+	}
+
+	if len(exampleList) > 0 {
+		strBuilder.WriteString("\nExamples:\n")
+	}
+
+	for _, example := range exampleList {
+		label := "Good"
+		if example.IsViolation {
+			label = "Bad"
+		}
+
+		strBuilder.WriteString("\n" + label)
+
+		if example.ExampleName != "" {
+			strBuilder.WriteString(" | " + example.ExampleName)
+		}
+
+		strBuilder.WriteRune('\n')
+
+		for _, line := range strings.Split(strings.TrimSpace(example.DocsContext), "\n") {
+			strBuilder.WriteString("    " + line + "\n")
+		}
+	}
+
+	strBuilder.WriteString("\nReference: " + string(rule.DocsReference()) + "\n")
+
+	printToOutput(strBuilder.String(), writer)
+}
@@ -0,0 +1,66 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Report "github.com/cremindes/whalelint/report"
+)
+
+func TestPrintRuleListAsJSON(t *testing.T) {
+	t.Parallel()
+
+	ruleList := RuleSet.Get().SortedRuleList()
+
+	buffer := &bytes.Buffer{}
+	Report.PrintRuleListAsJSON(ruleList, buffer)
+
+	var ruleListItemList []Report.RuleListItem
+
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &ruleListItemList))
+	assert.Equal(t, len(ruleList), len(ruleListItemList))
+	assert.Equal(t, Report.NewRuleListItem(&ruleList[0]), ruleListItemList[0])
+}
+
+func TestPrintRuleListAsTable(t *testing.T) {
+	t.Parallel()
+
+	rule := RuleSet.Get().GetRuleByName("RUN006", nil)
+
+	strBuilder := &strings.Builder{}
+	Report.PrintRuleListAsTable([]RuleSet.Rule{rule}, strBuilder)
+
+	lineList := strings.Split(strings.TrimSpace(strBuilder.String()), "\n")
+
+	assert.Equal(t, 2, len(lineList))
+	assert.True(t, strings.HasPrefix(lineList[0], "ID      SEVERITY"))
+	assert.Equal(t, []string{
+		"RUN006", "Warning", "*instructions.RunCommand", "Clean", "cache", "after", "package", "manager", "operation.",
+		"https://docs.docker.com/engine/reference/builder/#run",
+	}, strings.Fields(lineList[1]))
+}
+
+func TestPrintRuleExplanation(t *testing.T) {
+	t.Parallel()
+
+	rule := RuleSet.Get().GetRuleByName("RUN006", nil)
+	exampleList := []RuleSet.TestCaseDocs{
+		{ExampleName: "", DocsContext: "RUN apk add --no-cache git", IsViolation: false},
+		{ExampleName: "Cache is kept.", DocsContext: "FROM alpine\nRUN apk update", IsViolation: true},
+	}
+
+	strBuilder := &strings.Builder{}
+	Report.PrintRuleExplanation(&rule, exampleList, strBuilder)
+
+	assert.Equal(t, "RUN006 | Warning | *instructions.RunCommand\n\n"+
+		"Clean cache after package manager operation.\n\n"+
+		"Examples:\n\n"+
+		"Good\n    RUN apk add --no-cache git\n\n"+
+		"Bad | Cache is kept.\n    FROM alpine\n    RUN apk update\n\n"+
+		"Reference: https://docs.docker.com/engine/reference/builder/#run\n", strBuilder.String())
+}
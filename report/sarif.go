@@ -0,0 +1,237 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+)
+
+// SARIF 2.1.0 constants, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+const (
+	SarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	SarifVersion = "2.1.0"
+)
+
+// SarifLog is the top level SARIF object. Only the subset used by WhaleLint is modelled.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []SarifReportingDescriptor `json:"rules"`
+}
+
+type SarifReportingDescriptor struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     SarifMessage           `json:"shortDescription"`
+	FullDescription      *SarifMessage          `json:"fullDescription,omitempty"`
+	HelpURI              string                 `json:"helpUri,omitempty"`
+	DefaultConfiguration SarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           SarifRegion           `json:"region"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SarifRegion is 1-based both in lines and columns, where EndColumn points after the last character.
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// SarifLevel maps a Severity to a SARIF result level.
+func SarifLevel(severity RuleSet.Severity) string {
+	switch severity {
+	case RuleSet.ValError:
+		return "error"
+	case RuleSet.ValWarning, RuleSet.ValDeprecation:
+		return "warning"
+	case RuleSet.ValInfo:
+		return "note"
+	case RuleSet.ValUnknown:
+		return "none"
+	}
+
+	return "none"
+}
+
+// NewSarifLog converts the rule validation results into a SARIF log. Every rule that produced a result, violated or
+// not, is listed as a reportingDescriptor with its default severity, while only the violations are listed as results
+// with their configured severity.
+func NewSarifLog(ruleValidationResultArray []RuleSet.RuleValidationResult) SarifLog {
+	// collect the rules first, so results can reference them by index
+	ruleMap := make(map[string]*RuleSet.Rule)
+	registeredRuleMap := RuleSet.Get()
+
+	for i := range ruleValidationResultArray {
+		rule := ruleValidationResultArray[i].Rule()
+		if rule == nil {
+			continue
+		}
+
+		// the rule of a result may have its severity re-mapped by the config of its file, so the descriptor is built
+		// from the registered rule, while the re-mapped severity is the level of the result
+		if registeredRule := registeredRuleMap.GetRuleByName(rule.ID(), nil); registeredRule.ID() != "" {
+			rule = &registeredRule
+		}
+
+		ruleMap[rule.ID()] = rule
+	}
+
+	ruleIDList := make([]string, 0, len(ruleMap))
+	for ruleID := range ruleMap {
+		ruleIDList = append(ruleIDList, ruleID)
+	}
+
+	sort.Strings(ruleIDList)
+
+	ruleIndexMap := make(map[string]int, len(ruleIDList))
+	descriptorList := make([]SarifReportingDescriptor, len(ruleIDList))
+
+	for i, ruleID := range ruleIDList {
+		ruleIndexMap[ruleID] = i
+		descriptorList[i] = newSarifReportingDescriptor(ruleMap[ruleID])
+	}
+
+	resultList := make([]SarifResult, 0)
+
+	for i := range ruleValidationResultArray {
+		result := &ruleValidationResultArray[i]
+		if !result.IsViolated() || result.Rule() == nil {
+			continue
+		}
+
+		resultList = append(resultList, SarifResult{
+			RuleID:    result.RuleID(),
+			RuleIndex: ruleIndexMap[result.RuleID()],
+			Level:     SarifLevel(result.Severity()),
+			Message:   SarifMessage{Text: result.Message()},
+			Locations: []SarifLocation{newSarifLocation(result)},
+		})
+	}
+
+	return SarifLog{
+		Schema:  SarifSchema,
+		Version: SarifVersion,
+		Runs: []SarifRun{{
+			Tool: SarifTool{Driver: SarifDriver{
+				Name:           "WhaleLint",
+				InformationURI: "https://github.com/CreMindES/whalelint",
+				Rules:          descriptorList,
+			}},
+			Results: resultList,
+		}},
+	}
+}
+
+// PrintResultAsSARIF prints lint rule violations to writer in SARIF 2.1.0 format.
+func PrintResultAsSARIF(ruleValidationResultArray []RuleSet.RuleValidationResult, writer io.Writer) {
+	resultJSON, err := json.MarshalIndent(NewSarifLog(ruleValidationResultArray), "", "  ")
+	if err != nil {
+		log.Error(err)
+	}
+
+	printToOutput(string(resultJSON)+"\n", writer)
+}
+
+func newSarifReportingDescriptor(rule *RuleSet.Rule) SarifReportingDescriptor {
+	descriptor := SarifReportingDescriptor{
+		ID:                   rule.ID(),
+		ShortDescription:     SarifMessage{Text: rule.Definition()},
+		DefaultConfiguration: SarifRuleConfiguration{Level: SarifLevel(rule.Severity())},
+	}
+
+	if rule.Description() != "" {
+		descriptor.FullDescription = &SarifMessage{Text: rule.Description()}
+	}
+
+	if docsReference := string(rule.DocsReference()); strings.HasPrefix(docsReference, "http") {
+		descriptor.HelpURI = docsReference
+	}
+
+	return descriptor
+}
+
+func newSarifLocation(result *RuleSet.RuleValidationResult) SarifLocation {
+	region := SarifRegion{StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 1}
+
+	// SARIF line numbers start at 1, so results without a proper location are put at the beginning of the file
+	if start, end := result.LocationRange.Start(), result.LocationRange.End(); start != nil && end != nil &&
+		start.LineNumber() > 0 && end.LineNumber() >= start.LineNumber() {
+		region = SarifRegion{
+			StartLine:   start.LineNumber(),
+			StartColumn: start.CharNumber() + 1,
+			EndLine:     end.LineNumber(),
+			EndColumn:   end.CharNumber() + 1,
+		}
+	}
+
+	return SarifLocation{
+		PhysicalLocation: SarifPhysicalLocation{
+			ArtifactLocation: SarifArtifactLocation{URI: SarifURI(result.FilePath())},
+			Region:           region,
+		},
+	}
+}
+
+// SarifURI converts a file path into a SARIF artifact URI. Relative paths stay relative, so they can be resolved
+// against the repository root by code-scanning tools.
+func SarifURI(filePath string) string {
+	uri := filepath.ToSlash(filePath)
+
+	if filepath.IsAbs(filePath) {
+		if !strings.HasPrefix(uri, "/") { // Windows drive letter
+			uri = "/" + uri
+		}
+
+		return "file://" + uri
+	}
+
+	return strings.TrimPrefix(uri, "./")
+}
@@ -0,0 +1,111 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Report "github.com/cremindes/whalelint/report"
+)
+
+func TestSarifLevel(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gofmt,gofumpt,goimports
+	testCases := []struct {
+		Severity RuleSet.Severity
+		Expected string
+	}{
+		{Severity: RuleSet.ValError,       Expected: "error"  },
+		{Severity: RuleSet.ValWarning,     Expected: "warning"},
+		{Severity: RuleSet.ValInfo,        Expected: "note"   },
+		{Severity: RuleSet.ValDeprecation, Expected: "warning"},
+		{Severity: RuleSet.ValUnknown,     Expected: "none"   },
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Severity.String(), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.Expected, Report.SarifLevel(testCase.Severity))
+		})
+	}
+}
+
+func TestSarifURI(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "api/Dockerfile", Report.SarifURI("./api/Dockerfile"))
+	assert.Equal(t, "file:///tmp/Dockerfile", Report.SarifURI("/tmp/Dockerfile"))
+}
+
+func TestPrintResultAsSARIF(t *testing.T) {
+	t.Parallel()
+
+	results := []RuleSet.RuleValidationResult{
+		newResult(t, "STS001", true, 1, "Dockerfile"),
+		newResult(t, "RUN004", false, 2, "Dockerfile"),
+		newResult(t, "RUN004", true, 3, "api/Dockerfile"),
+	}
+
+	buffer := &bytes.Buffer{}
+	Report.PrintResultAsSARIF(results, buffer)
+
+	var sarifLog Report.SarifLog
+
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &sarifLog))
+	assert.Equal(t, "2.1.0", sarifLog.Version)
+	assert.Equal(t, 1, len(sarifLog.Runs))
+
+	run := sarifLog.Runs[0]
+
+	// every rule is described once, sorted by ID
+	assert.Equal(t, 2, len(run.Tool.Driver.Rules))
+	assert.Equal(t, "RUN004", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "STS001", run.Tool.Driver.Rules[1].ID)
+	assert.Equal(t, "https://docs.docker.com/engine/reference/builder/#run", run.Tool.Driver.Rules[0].HelpURI)
+	assert.Equal(t, "warning", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+
+	// only the violations are results
+	assert.Equal(t, 2, len(run.Results))
+	assert.Equal(t, "STS001", run.Results[0].RuleID)
+	assert.Equal(t, 1, run.Results[0].RuleIndex)
+	assert.Equal(t, "Mock message.", run.Results[0].Message.Text)
+	assert.Equal(t, "RUN004", run.Results[1].RuleID)
+	assert.Equal(t, 0, run.Results[1].RuleIndex)
+
+	location := run.Results[1].Locations[0].PhysicalLocation
+	assert.Equal(t, "api/Dockerfile", location.ArtifactLocation.URI)
+	assert.Equal(t, Report.SarifRegion{StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 11}, location.Region)
+}
+
+func TestPrintResultAsSARIF_SeverityOverride(t *testing.T) {
+	t.Parallel()
+
+	overriddenResult := newResult(t, "RUN004", true, 2, "api/Dockerfile")
+	overriddenResult.Rule().SetSeverity(RuleSet.ValError)
+
+	// the descriptor does not depend on, which file's result comes last
+	for _, results := range [][]RuleSet.RuleValidationResult{
+		{newResult(t, "RUN004", true, 2, "Dockerfile"), overriddenResult},
+		{overriddenResult, newResult(t, "RUN004", true, 2, "Dockerfile")},
+	} {
+		sarifLog := Report.NewSarifLog(results)
+		run := sarifLog.Runs[0]
+
+		assert.Equal(t, "warning", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+
+		for _, result := range run.Results {
+			if result.Locations[0].PhysicalLocation.ArtifactLocation.URI == "api/Dockerfile" {
+				assert.Equal(t, "error", result.Level)
+			} else {
+				assert.Equal(t, "warning", result.Level)
+			}
+		}
+	}
+}
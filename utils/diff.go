@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffContextLineCount is the number of unchanged lines shown around the changes in a unified diff.
+const DiffContextLineCount = 3
+
+// diffLine is a line of a diff with its kind, i.e. ' ' for unchanged, '-' for removed and '+' for added lines.
+type diffLine struct {
+	kind    byte
+	text    string
+	oldLine int // 0-based index of the line in the old text, or of the next one in case of an added line
+	newLine int // 0-based index of the line in the new text, or of the next one in case of a removed line
+}
+
+// UnifiedDiff returns the line based unified diff of oldStr and newStr, as printed by diff -u. It returns an empty
+// string, if they are the same.
+func UnifiedDiff(oldName, newName, oldStr, newStr string) string {
+	if oldStr == newStr {
+		return ""
+	}
+
+	diffLineList := diffLines(splitLines(oldStr), splitLines(newStr))
+
+	strBuilder := strings.Builder{}
+	strBuilder.WriteString("--- " + oldName + "\n")
+	strBuilder.WriteString("+++ " + newName + "\n")
+
+	for start := 0; start < len(diffLineList); {
+		// find the next change
+		for start < len(diffLineList) && diffLineList[start].kind == ' ' {
+			start++
+		}
+
+		if start == len(diffLineList) {
+			break
+		}
+
+		// extend the hunk, while the changes are close enough to share context lines
+		end := start
+		for unchangedCount := 0; end < len(diffLineList) && unchangedCount <= 2*DiffContextLineCount; end++ {
+			if diffLineList[end].kind == ' ' {
+				unchangedCount++
+			} else {
+				unchangedCount = 0
+			}
+		}
+
+		for end > start && diffLineList[end-1].kind == ' ' {
+			end--
+		}
+
+		hunkStart := maxInt(start-DiffContextLineCount, 0)
+		hunkEnd := minInt(end+DiffContextLineCount, len(diffLineList))
+
+		writeHunk(&strBuilder, diffLineList[hunkStart:hunkEnd])
+
+		start = hunkEnd
+	}
+
+	return strBuilder.String()
+}
+
+// writeHunk writes the header and the lines of a hunk.
+func writeHunk(strBuilder *strings.Builder, hunk []diffLine) {
+	oldCount, newCount := 0, 0
+
+	for _, line := range hunk {
+		if line.kind != '+' {
+			oldCount++
+		}
+
+		if line.kind != '-' {
+			newCount++
+		}
+	}
+
+	// an empty range is denoted by the line before it
+	oldStart, newStart := hunk[0].oldLine+1, hunk[0].newLine+1
+	if oldCount == 0 {
+		oldStart--
+	}
+
+	if newCount == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(strBuilder, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+
+	for _, line := range hunk {
+		strBuilder.WriteString(string(line.kind) + line.text + "\n")
+	}
+}
+
+// diffLines returns the shortest edit script from oldLineList to newLineList, based on their longest common
+// subsequence. Dockerfiles are short, so the quadratic algorithm is fine.
+func diffLines(oldLineList, newLineList []string) []diffLine {
+	oldLen, newLen := len(oldLineList), len(newLineList)
+
+	// lcsLen[i][j] is the length of the longest common subsequence of oldLineList[i:] and newLineList[j:]
+	lcsLen := make([][]int, oldLen+1)
+	for i := range lcsLen {
+		lcsLen[i] = make([]int, newLen+1)
+	}
+
+	for i := oldLen - 1; i >= 0; i-- {
+		for j := newLen - 1; j >= 0; j-- {
+			if oldLineList[i] == newLineList[j] {
+				lcsLen[i][j] = lcsLen[i+1][j+1] + 1
+			} else {
+				lcsLen[i][j] = maxInt(lcsLen[i+1][j], lcsLen[i][j+1])
+			}
+		}
+	}
+
+	diffLineList := make([]diffLine, 0, oldLen+newLen)
+	i, j := 0, 0
+
+	for i < oldLen || j < newLen {
+		switch {
+		case i < oldLen && j < newLen && oldLineList[i] == newLineList[j]:
+			diffLineList = append(diffLineList, diffLine{kind: ' ', text: oldLineList[i], oldLine: i, newLine: j})
+			i++
+			j++
+		case j == newLen || (i < oldLen && lcsLen[i+1][j] >= lcsLen[i][j+1]):
+			diffLineList = append(diffLineList, diffLine{kind: '-', text: oldLineList[i], oldLine: i, newLine: j})
+			i++
+		default:
+			diffLineList = append(diffLineList, diffLine{kind: '+', text: newLineList[j], oldLine: i, newLine: j})
+			j++
+		}
+	}
+
+	return diffLineList
+}
+
+// splitLines splits str into lines, without the empty one after the closing newline.
+func splitLines(str string) []string {
+	if str == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimSuffix(str, "\n"), "\n")
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
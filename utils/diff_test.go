@@ -0,0 +1,56 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		OldStr       string
+		NewStr       string
+		ExpectedDiff string
+	}{
+		{
+			Name:         "No change.",
+			OldStr:       "FROM golang\n",
+			NewStr:       "FROM golang\n",
+			ExpectedDiff: "",
+		},
+		{
+			Name:   "Single changed line.",
+			OldStr: "FROM golang\nWORKDIR app\nCMD date\n",
+			NewStr: "FROM golang\nWORKDIR /app\nCMD date\n",
+			ExpectedDiff: "--- a\n+++ b\n@@ -1,3 +1,3 @@\n FROM golang\n-WORKDIR app\n+WORKDIR /app\n CMD date\n",
+		},
+		{
+			Name:   "Distant changes in separate hunks.",
+			OldStr: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			NewStr: "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n12\n",
+			ExpectedDiff: "--- a\n+++ b\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n" +
+				"@@ -8,5 +9,4 @@\n 8\n 9\n 10\n-11\n 12\n",
+		},
+		{
+			Name:         "Added lines to empty content.",
+			OldStr:       "",
+			NewStr:       "FROM golang\n",
+			ExpectedDiff: "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+FROM golang\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.ExpectedDiff, Utils.UnifiedDiff("a", "b", testCase.OldStr, testCase.NewStr))
+		})
+	}
+}
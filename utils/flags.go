@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+)
+
+/* Instruction flags. */
+
+// newerFlagMap is the list of instruction flags per instruction keyword, that Docker builds, but the buildkit parser
+// of WhaleLint does not know yet, e.g. ADD --checksum. They are removed from the AST before building the commands,
+// so the instructions are linted instead of being reported as invalid. The original text keeps them.
+var newerFlagMap = map[string][]string{ // nolint:gochecknoglobals
+	"add":  {"checksum", "keep-git-dir", "link"},
+	"copy": {"link"},
+}
+
+// RemoveNewerFlags removes the newerFlagMap flags of the instructions of the Dockerfile AST.
+func RemoveNewerFlags(ast *parser.Node) {
+	if ast == nil {
+		return
+	}
+
+	for _, node := range ast.Children {
+		removeNewerFlagsOfNode(node)
+	}
+}
+
+func removeNewerFlagsOfNode(node *parser.Node) {
+	newerFlagList, ok := newerFlagMap[strings.ToLower(node.Value)]
+	if !ok {
+		return
+	}
+
+	flagList := make([]string, 0, len(node.Flags))
+
+	for _, flag := range node.Flags {
+		name, _ := SplitKeyValue(strings.TrimPrefix(flag, "--"), '=')
+		if !EqualsEither(name, newerFlagList) {
+			flagList = append(flagList, flag)
+		}
+	}
+
+	node.Flags = flagList
+}
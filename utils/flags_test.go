@@ -0,0 +1,62 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/stretchr/testify/assert"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+func TestRemoveNewerFlags(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name        string
+		Instruction string
+		Expected    []string
+	}{
+		{
+			Name:        "ADD --checksum.",
+			Instruction: "ADD --checksum=sha256:24454f83 --chown=app https://example.com/app.tar.gz /app/",
+			Expected:    []string{"--chown=app"},
+		},
+		{
+			Name:        "ADD --keep-git-dir.",
+			Instruction: "ADD --keep-git-dir=true https://github.com/moby/buildkit.git#v0.10.1 /src",
+			Expected:    []string{},
+		},
+		{
+			Name:        "COPY --link.",
+			Instruction: "copy --link --from=build /app /app",
+			Expected:    []string{"--from=build"},
+		},
+		{
+			Name:        "Unknown flag.",
+			Instruction: "COPY --parents src/*/go.mod /app/",
+			Expected:    []string{"--parents"},
+		},
+		{
+			Name:        "Flag of another instruction.",
+			Instruction: "RUN --link make",
+			Expected:    []string{"--link"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			dockerfile, err := parser.Parse(strings.NewReader(testCase.Instruction))
+			assert.Nil(t, err)
+
+			Utils.RemoveNewerFlags(dockerfile.AST)
+
+			assert.Equal(t, testCase.Expected, dockerfile.AST.Children[0].Flags)
+		})
+	}
+}
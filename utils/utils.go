@@ -244,16 +244,24 @@ func GetDockerfileAst(filePathString string) ([]instructions.Stage, []instructio
 	}
 	defer fileHandle.Close()
 
-	dockerfile, err := parser.Parse(fileHandle)
+	return ParseDockerfileAst(fileHandle)
+}
+
+// ParseDockerfileAst parses the Dockerfile content of readSeeker, e.g. an opened file or a strings.Reader of an
+// unsaved editor buffer, into its stages and meta args.
+func ParseDockerfileAst(readSeeker io.ReadSeeker) ([]instructions.Stage, []instructions.ArgCommand, error) {
+	dockerfile, err := parser.Parse(readSeeker)
 	if err != nil {
-		// log.Error("Cannot parse Dockerfile \"", filePath, "\"", err)
+		// log.Error("Cannot parse Dockerfile", err)
 		return nil, nil, fmt.Errorf("dockerfile parse | %w", err)
 	}
 
+	RemoveNewerFlags(dockerfile.AST)
+
 	stageList, metaArgs, err := instructions.Parse(dockerfile.AST)
 	if err != nil {
-		log.Debug("Cannot create Dockerfile AST from \"", filePath, "\".", err)
-		stageList, metaArgs = ParseDockerfileInstructionsSafely(dockerfile, fileHandle) // nolint:wsl
+		log.Debug("Cannot create Dockerfile AST.", err)
+		stageList, metaArgs = ParseDockerfileInstructionsSafely(dockerfile, readSeeker) // nolint:wsl
 	}
 
 	return stageList, metaArgs, nil
@@ -276,6 +284,8 @@ func ParseDockerfileInstructionsSafely(dockerfile *parser.Result, fileHandle io.
 		return []instructions.Stage{}, []instructions.ArgCommand{}
 	}
 
+	RemoveNewerFlags(dockerfile.AST)
+
 	var (
 		stageList []instructions.Stage
 		metaArgs  []instructions.ArgCommand
@@ -288,8 +298,15 @@ func ParseDockerfileInstructionsSafely(dockerfile *parser.Result, fileHandle io.
 		if err != nil {
 			log.Trace("Cannot create Dockerfile AST", err)
 			// parse offending node number
-			regexpOffendingLine := regexp.MustCompile(" parse error line ([1-9]+[0-9]*):")
+			regexpOffendingLine := regexp.MustCompile(" parse error (?:on )?line ([1-9]+[0-9]*):")
 			strSlice := regexpOffendingLine.FindStringSubmatch(err.Error())
+
+			if strSlice == nil {
+				log.Error("Cannot locate the offending line of the Dockerfile AST error.", err)
+
+				return []instructions.Stage{}, []instructions.ArgCommand{}
+			}
+
 			offendingLineIndex, _ := strconv.Atoi(strSlice[1])
 
 			_, errSeek := fileHandle.Seek(0, 0) // go back to the beginning of the file
@@ -320,11 +337,16 @@ func ParseDockerfileInstructionsSafely(dockerfile *parser.Result, fileHandle io.
 				}
 			}
 
-			if offendingAstIdx > 0 {
-				dockerfile.AST.Children = append(
-					dockerfile.AST.Children[:offendingAstIdx],
-					dockerfile.AST.Children[offendingAstIdx+1:]...)
+			if offendingAstIdx <= 0 {
+				// nothing to remove, so it would fail the same way again
+				log.Error("Fallback to Dockerfile AST correction was unsuccessful.", err)
+
+				return []instructions.Stage{}, []instructions.ArgCommand{}
 			}
+
+			dockerfile.AST.Children = append(
+				dockerfile.AST.Children[:offendingAstIdx],
+				dockerfile.AST.Children[offendingAstIdx+1:]...)
 		}
 
 		if len(dockerfile.AST.Children) == 0 {
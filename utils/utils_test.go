@@ -2,6 +2,7 @@
 package utils_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -484,3 +485,18 @@ func TestMatchDockerImageNames(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDockerfileAst(t *testing.T) {
+	t.Parallel()
+
+	// the invalid instruction is skipped, e.g. while it's being typed
+	stageList, _, err := Utils.ParseDockerfileAst(strings.NewReader("FROM golang\nWORKDIR \nRUN date\n"))
+	assert.Nil(t, err)
+	assert.Len(t, stageList, 1)
+	assert.Len(t, stageList[0].Commands, 1)
+
+	// nothing left to parse
+	stageList, _, err = Utils.ParseDockerfileAst(strings.NewReader("FROM\n"))
+	assert.Nil(t, err)
+	assert.Empty(t, stageList)
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/* Dockerfile discovery. */
+
+// DockerfileNamePatternList is the list of file name patterns that are recognized as Dockerfiles while walking a
+// directory tree.
+var DockerfileNamePatternList = []string{ // nolint:gochecknoglobals
+	"Dockerfile",
+	"*.Dockerfile",
+	"Dockerfile.*",
+	"Containerfile",
+}
+
+// skippedDirNameList is the list of directory names that are never walked.
+var skippedDirNameList = []string{".git"} // nolint:gochecknoglobals
+
+// IsDockerfileName returns true, if the file name matches any of the DockerfileNamePatternList patterns.
+func IsDockerfileName(fileName string) bool {
+	for _, pattern := range DockerfileNamePatternList {
+		if match, _ := filepath.Match(pattern, fileName); match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CollectDockerfiles returns the sorted list of Dockerfiles from pathList.
+//
+// File paths are returned as they are, even if they don't exist, so the caller can report the error for each of them.
+// Directories are walked recursively and the files are selected, that
+//   - either have a Dockerfile name (see DockerfileNamePatternList) or match any of the includeList globs
+//   - and do not match any of the excludeList globs.
+//
+// Globs are matched against the slash separated path relative to the walked directory. They support "**" for any
+// number of directories and a glob without "/" is matched against the file name only, e.g. "**/test/**" or "*.dev".
+func CollectDockerfiles(pathList, includeList, excludeList []string) ([]string, error) {
+	resultSet := make(map[string]bool)
+
+	for _, path := range pathList {
+		fileInfo, err := os.Stat(path)
+		if err != nil || !fileInfo.IsDir() {
+			resultSet[path] = true
+
+			continue
+		}
+
+		dirPath := path
+
+		err = filepath.WalkDir(dirPath, func(path string, dirEntry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if dirEntry.IsDir() {
+				if path != dirPath && EqualsEither(dirEntry.Name(), skippedDirNameList) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			relPath, err := filepath.Rel(dirPath, path)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+
+			relPath = filepath.ToSlash(relPath)
+
+			if (IsDockerfileName(dirEntry.Name()) || MatchGlobEither(relPath, includeList)) &&
+				!MatchGlobEither(relPath, excludeList) {
+				resultSet[path] = true
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+	}
+
+	result := make([]string, 0, len(resultSet))
+	for path := range resultSet {
+		result = append(result, path)
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// MatchGlobEither returns true, if the slash separated path matches any of the globs. For the glob format, please see
+// CollectDockerfiles.
+func MatchGlobEither(path string, globList []string) bool {
+	for _, glob := range globList {
+		if MatchGlob(path, glob) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchGlob returns true, if the slash separated path matches the glob. For the glob format, please see
+// CollectDockerfiles.
+func MatchGlob(path string, glob string) bool {
+	glob = filepath.ToSlash(glob)
+
+	if !strings.Contains(glob, "/") {
+		path = path[strings.LastIndex(path, "/")+1:]
+	}
+
+	return globToRegexp(glob).MatchString(path)
+}
+
+// globToRegexp converts a glob into an anchored regular expression.
+func globToRegexp(glob string) *regexp.Regexp {
+	strBuilder := strings.Builder{}
+	strBuilder.WriteRune('^')
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			strBuilder.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			strBuilder.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			strBuilder.WriteString("[^/]*")
+		case glob[i] == '?':
+			strBuilder.WriteString("[^/]")
+		default:
+			strBuilder.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+
+	strBuilder.WriteRune('$')
+
+	return regexp.MustCompile(strBuilder.String())
+}
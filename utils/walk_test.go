@@ -0,0 +1,149 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+func TestIsDockerfileName(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gofmt,gofumpt,goimports
+	testCases := []struct {
+		fileName string
+		expected bool
+	}{
+		{fileName: "Dockerfile",         expected:  true},
+		{fileName: "api.Dockerfile",     expected:  true},
+		{fileName: "Dockerfile.dev",     expected:  true},
+		{fileName: "Containerfile",      expected:  true},
+		{fileName: "Dockerfile_old",     expected: false},
+		{fileName: "docker-compose.yml", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.fileName, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, Utils.IsDockerfileName(testCase.fileName))
+		})
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	t.Parallel()
+
+	// nolint:gofmt,gofumpt,goimports
+	testCases := []struct {
+		path     string
+		glob     string
+		expected bool
+		name     string
+	}{
+		{path: "Dockerfile",               glob: "Dockerfile",      expected:  true, name: "exact match"               },
+		{path: "api/Dockerfile",           glob: "Dockerfile",      expected:  true, name: "file name only glob"       },
+		{path: "api/Dockerfile",           glob: "*/Dockerfile",    expected:  true, name: "one directory"             },
+		{path: "a/b/Dockerfile",           glob: "*/Dockerfile",    expected: false, name: "star does not cross slash" },
+		{path: "a/b/Dockerfile",           glob: "**/Dockerfile",   expected:  true, name: "double star"               },
+		{path: "Dockerfile",               glob: "**/Dockerfile",   expected:  true, name: "double star, no directory" },
+		{path: "test/fixtures/Dockerfile", glob: "test/**",         expected:  true, name: "double star suffix"        },
+		{path: "src/test/Dockerfile",      glob: "test/**",         expected: false, name: "anchored to the root"      },
+		{path: "src/test/Dockerfile",      glob: "**/test/**",      expected:  true, name: "double star both sides"    },
+		{path: "app/build.docker",         glob: "*.docker",        expected:  true, name: "extension"                 },
+		{path: "app/Dockerfile.1",         glob: "Dockerfile.?",    expected:  true, name: "question mark"             },
+		{path: "app/Dockerfile.10",        glob: "Dockerfile.?",    expected: false, name: "question mark, single char"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, Utils.MatchGlob(testCase.path, testCase.glob))
+		})
+	}
+}
+
+func TestCollectDockerfiles(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	for _, relPath := range []string{
+		"Dockerfile",
+		"api/Dockerfile",
+		"api/Dockerfile.dev",
+		"web/web.Dockerfile",
+		"web/Containerfile",
+		"web/build.docker",
+		"web/README.md",
+		"test/fixtures/Dockerfile",
+		".git/Dockerfile",
+	} {
+		path := filepath.Join(rootDir, filepath.FromSlash(relPath))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.Nil(t, os.WriteFile(path, []byte("FROM golang:1.17\n"), 0o600))
+	}
+
+	testCases := []struct {
+		Name        string
+		PathList    []string
+		IncludeList []string
+		ExcludeList []string
+		Expected    []string
+	}{
+		{
+			Name:     "Walk directory.",
+			PathList: []string{rootDir},
+			Expected: []string{
+				"Dockerfile", "api/Dockerfile", "api/Dockerfile.dev", "test/fixtures/Dockerfile",
+				"web/Containerfile", "web/web.Dockerfile",
+			},
+		},
+		{
+			Name:        "Walk directory with include and exclude globs.",
+			PathList:    []string{rootDir},
+			IncludeList: []string{"*.docker"},
+			ExcludeList: []string{"**/test/**", "*.dev"},
+			Expected: []string{
+				"Dockerfile", "api/Dockerfile", "web/Containerfile", "web/build.docker", "web/web.Dockerfile",
+			},
+		},
+		{
+			Name:     "Explicit files are kept, even if they don't exist.",
+			PathList: []string{filepath.Join(rootDir, "web/README.md"), filepath.Join(rootDir, "missing")},
+			Expected: []string{"missing", "web/README.md"},
+		},
+		{
+			Name:     "Duplicates are removed.",
+			PathList: []string{filepath.Join(rootDir, "api"), filepath.Join(rootDir, "api/Dockerfile")},
+			Expected: []string{"api/Dockerfile", "api/Dockerfile.dev"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Utils.CollectDockerfiles(testCase.PathList, testCase.IncludeList, testCase.ExcludeList)
+			assert.Nil(t, err)
+
+			expected := make([]string, len(testCase.Expected))
+			for i, relPath := range testCase.Expected {
+				expected[i] = filepath.Join(rootDir, filepath.FromSlash(relPath))
+			}
+
+			assert.Equal(t, expected, result)
+		})
+	}
+}
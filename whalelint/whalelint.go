@@ -0,0 +1,282 @@
+// Package whalelint is the Go library API of WhaleLint, for embedding the linter in other tools without the CLI.
+//
+//	report, err := whalelint.Lint(ctx, source, whalelint.Options{FileName: "api/Dockerfile"})
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, finding := range report.Findings {
+//		fmt.Println(finding.Range.Start.Line, finding.RuleID, finding.Message)
+//	}
+//
+// The functions are safe for concurrent use, so many Dockerfiles can be linted in parallel.
+package whalelint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	Config "github.com/cremindes/whalelint/config"
+	Linter "github.com/cremindes/whalelint/linter"
+	RuleSet "github.com/cremindes/whalelint/linter/ruleset"
+	Utils "github.com/cremindes/whalelint/utils"
+)
+
+// Severity is the severity of a finding, e.g. SeverityError.
+type Severity = RuleSet.Severity
+
+const (
+	SeverityError       = RuleSet.ValError
+	SeverityWarning     = RuleSet.ValWarning
+	SeverityInfo        = RuleSet.ValInfo
+	SeverityDeprecation = RuleSet.ValDeprecation
+)
+
+var ErrUnknownRule = errors.New("unknown rule")
+
+// Options are the settings of a lint run. The zero value lints with every rule enabled with its default severity and
+// without touching the file system.
+type Options struct {
+	// FileName is the path of the Dockerfile, that the findings are reported with. It's optional.
+	FileName string
+	// Config is the config to lint with. It takes precedence over ConfigPath and DiscoverConfig.
+	Config *Config.Config
+	// ConfigPath is the path of the config file to lint with.
+	ConfigPath string
+	// DiscoverConfig looks for a config file next to FileName and in its parent directories, as the CLI does.
+	DiscoverConfig bool
+	// EnableRules limits the linting to these rules, if it's not empty.
+	EnableRules []string
+	// DisableRules are not validated, even if they are enabled by EnableRules.
+	DisableRules []string
+}
+
+// Position is a position in the Dockerfile. Line is 1-based, Column is 0-based.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Range is the range of the Dockerfile, that a finding belongs to.
+type Range struct {
+	Start Position
+	End   Position
+}
+
+// Finding is a violation of a rule.
+type Finding struct {
+	RuleID      string
+	Severity    Severity
+	Message     string
+	Definition  string
+	FilePath    string
+	Range       Range
+	Instruction string
+	// Fingerprint identifies the finding independently of its line number, see RuleValidationResult.Fingerprint.
+	Fingerprint string
+	Fixable     bool
+}
+
+// Report is the result of a lint run.
+type Report struct {
+	FileName string
+	Findings []Finding
+
+	resultList []RuleSet.RuleValidationResult
+}
+
+// HasFindings tells, whether there is a finding with at least the severity threshold.
+func (report Report) HasFindings(threshold Severity) bool {
+	for _, finding := range report.Findings {
+		if finding.Severity.IsAtLeast(threshold) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidationResults returns the rule validation results of the run, e.g. to print them with the report package.
+func (report Report) ValidationResults() []RuleSet.RuleValidationResult {
+	return report.resultList
+}
+
+// Lint lints the Dockerfile source.
+func Lint(ctx context.Context, source string, options Options) (Report, error) {
+	if err := ctx.Err(); err != nil {
+		return Report{}, fmt.Errorf("whalelint | %w", err)
+	}
+
+	linter, err := newLinter(options)
+	if err != nil {
+		return Report{}, err
+	}
+
+	resultList, err := linter.RunSource(options.FileName, source)
+	if err != nil {
+		return Report{}, fmt.Errorf("whalelint | %w", err)
+	}
+
+	findingList := make([]Finding, 0)
+
+	for i := range resultList {
+		resultList[i].SetFilePath(options.FileName)
+
+		if resultList[i].IsViolated() {
+			findingList = append(findingList, newFinding(&resultList[i]))
+		}
+	}
+
+	sort.SliceStable(findingList, func(i, j int) bool {
+		if findingList[i].Range.Start.Line != findingList[j].Range.Start.Line {
+			return findingList[i].Range.Start.Line < findingList[j].Range.Start.Line
+		}
+
+		return findingList[i].Range.Start.Column < findingList[j].Range.Start.Column
+	})
+
+	return Report{FileName: options.FileName, Findings: findingList, resultList: resultList}, nil
+}
+
+// LintReader reads the Dockerfile source from reader, e.g. os.Stdin, and lints it, see Lint.
+func LintReader(ctx context.Context, reader io.Reader, options Options) (Report, error) {
+	source, err := io.ReadAll(reader)
+	if err != nil {
+		return Report{}, fmt.Errorf("whalelint | %w", err)
+	}
+
+	return Lint(ctx, string(source), options)
+}
+
+// LintFile reads the Dockerfile at filePath and lints it, see Lint. The findings are reported with filePath, unless
+// options.FileName is set.
+func LintFile(ctx context.Context, filePath string, options Options) (Report, error) {
+	source, err := Utils.ReadFileContents(filePath)
+	if err != nil {
+		return Report{}, fmt.Errorf("whalelint | %s | %w", filePath, err)
+	}
+
+	if options.FileName == "" {
+		options.FileName = filePath
+	}
+
+	return Lint(ctx, source, options)
+}
+
+// Fix applies the autofixes of the fixable findings to the Dockerfile source. It returns the fixed source and the
+// number of applied edits.
+func Fix(ctx context.Context, source string, options Options) (string, int, error) {
+	if err := ctx.Err(); err != nil {
+		return source, 0, fmt.Errorf("whalelint | %w", err)
+	}
+
+	linter, err := newLinter(options)
+	if err != nil {
+		return source, 0, err
+	}
+
+	fixedSource, fixCount, err := linter.Fix(source)
+	if err != nil {
+		return source, 0, fmt.Errorf("whalelint | %w", err)
+	}
+
+	return fixedSource, fixCount, nil
+}
+
+// newLinter returns a linter with the config of options.
+func newLinter(options Options) (*Linter.Linter, error) {
+	config := options.Config
+
+	if config == nil {
+		var err error
+
+		switch {
+		case options.ConfigPath != "":
+			config, err = Config.Load(options.ConfigPath)
+		case options.DiscoverConfig && options.FileName != "":
+			config, err = Config.Resolve("", options.FileName)
+		default:
+			config = Config.Default()
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("whalelint | %w", err)
+		}
+	}
+
+	ruleConfig, err := ruleSelectionConfig(options.EnableRules, options.DisableRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Linter.Linter{Config: config.Merge(ruleConfig)}, nil
+}
+
+// ruleSelectionConfig returns a config, that disables the rules, that are not enabled, or are disabled explicitly.
+func ruleSelectionConfig(enableRuleList, disableRuleList []string) (*Config.Config, error) {
+	ruleIDList := make([]string, 0)
+
+	for _, rule := range RuleSet.Get().SortedRuleList() {
+		ruleIDList = append(ruleIDList, rule.ID())
+	}
+
+	enableRuleList, err := normalizeRuleIDs(enableRuleList, ruleIDList)
+	if err != nil {
+		return nil, err
+	}
+
+	disableRuleList, err = normalizeRuleIDs(disableRuleList, ruleIDList)
+	if err != nil {
+		return nil, err
+	}
+
+	config := Config.Default()
+
+	for _, ruleID := range ruleIDList {
+		if len(enableRuleList) > 0 && !Utils.EqualsEither(ruleID, enableRuleList) ||
+			Utils.EqualsEither(ruleID, disableRuleList) {
+			config.Disable = append(config.Disable, ruleID)
+		}
+	}
+
+	return config, nil
+}
+
+// normalizeRuleIDs converts the rule IDs to uppercase, as in the config file, and checks, that each of them exists.
+func normalizeRuleIDs(ruleIDList []string, knownRuleIDList []string) ([]string, error) {
+	result := make([]string, 0, len(ruleIDList))
+
+	for _, ruleID := range ruleIDList {
+		if !Utils.EqualsEither(strings.ToUpper(ruleID), knownRuleIDList) {
+			return nil, fmt.Errorf("whalelint | %w: %s", ErrUnknownRule, ruleID)
+		}
+
+		result = append(result, strings.ToUpper(ruleID))
+	}
+
+	return result, nil
+}
+
+// newFinding returns the finding of the violated result.
+func newFinding(result *RuleSet.RuleValidationResult) Finding {
+	location := result.Location()
+
+	return Finding{
+		RuleID:      result.RuleID(),
+		Severity:    result.Severity(),
+		Message:     result.Message(),
+		Definition:  result.Rule().Definition(),
+		FilePath:    result.FilePath(),
+		Range: Range{
+			Start: Position{Line: location.Start().LineNumber(), Column: location.Start().CharNumber()},
+			End:   Position{Line: location.End().LineNumber(), Column: location.End().CharNumber()},
+		},
+		Instruction: result.Instruction(),
+		Fingerprint: result.Fingerprint(),
+		Fixable:     result.Rule().IsFixable(),
+	}
+}